@@ -0,0 +1,150 @@
+package main
+
+// aaScale is the number of samples per pixel along each axis used by DrawAA
+// Each pixel is split into 2x2 samples, so the virtual display has 4 times the pixels
+const aaScale = 2
+
+// scalePoint returns the sample at or just after the center of pixel p when every pixel is split
+// into k x k samples; for an even k no sample lies exactly on the center
+func scalePoint(p Point, k int) Point {
+	return Point{p.x*k + k/2, p.y*k + k/2}
+}
+
+// scaleCorner returns the first sample of pixel p when every pixel is split into k x k samples
+func scaleCorner(p Point, k int) Point {
+	return Point{p.x * k, p.y * k}
+}
+
+// scaleThickness returns the outline width of a shape scaled up k times
+func scaleThickness(thickness, k int) int {
+	return thickness * k
+}
+
+// scaleShape returns a copy of the shape scaled up for a display with k x k samples per pixel,
+// including its outline width
+// Vertices and centers move to the sample given by scalePoint; a rectangle, which covers
+// the pixels from ll up to but not including ur, covers every sample of those pixels
+func scaleShape(g geometry, k int) geometry {
+	switch s := g.(type) {
+	case Rectangle:
+		s.ll, s.ur, s.Thickness = scaleCorner(s.ll, k), scaleCorner(s.ur, k), scaleThickness(s.Thickness, k)
+		return s
+	case Triangle:
+		s.pt0, s.pt1, s.pt2 = scalePoint(s.pt0, k), scalePoint(s.pt1, k), scalePoint(s.pt2, k)
+		s.Thickness = scaleThickness(s.Thickness, k)
+		return s
+	case Circle:
+		s.center, s.r, s.Thickness = scalePoint(s.center, k), s.r*k+k/2, scaleThickness(s.Thickness, k)
+		return s
+	case Line:
+		s.p0, s.p1, s.Thickness = scalePoint(s.p0, k), scalePoint(s.p1, k), scaleThickness(s.Thickness, k)
+		return s
+	case Polyline:
+		s.points = s.Vertices()
+		for i := range s.points {
+			s.points[i] = scalePoint(s.points[i], k)
+		}
+		s.Thickness = scaleThickness(s.Thickness, k)
+		return s
+	case Polygon:
+		s.points = s.Vertices()
+		for i := range s.points {
+			s.points[i] = scalePoint(s.points[i], k)
+		}
+		s.Thickness = scaleThickness(s.Thickness, k)
+		return s
+	case Parallelogram:
+		s.ll, s.width, s.height, s.shear = scalePoint(s.ll, k), s.width*k, s.height*k, s.shear*k
+		s.Thickness = scaleThickness(s.Thickness, k)
+		return s
+	}
+	return g
+}
+
+// drawAA draws the shape on the screen in the default mode with aaScale x aaScale supersampling
+// The shape is drawn scaled up on a virtual display aaScale times as wide and as high; each screen
+// pixel then gets the average color of the samples in its aaScale x aaScale block that the shape
+// covered, blended over the existing pixel by the covered fraction
+// Since scalePoint rounds the pixel center to a sample, edges may be half a sample off center
+// Returns the same errors as DrawOn
+func drawAA(g geometry, scn screen) (err error) {
+	maxX, maxY := scn.getMaxXY()
+	samples := newCoverageDisplay(maxX*aaScale, maxY*aaScale)
+	if !checksBounds(scn) {
+		samples.bounds = BoundsClip
+	}
+	if err = scaleShape(g, aaScale).DrawOn(samples, DrawDefault); err != nil {
+		return err
+	}
+
+	for x := 0; x < maxX; x++ {
+		for y := 0; y < maxY; y++ {
+			var sum RGB
+			covered := 0
+			for sx := x * aaScale; sx < (x+1)*aaScale; sx++ {
+				for sy := y * aaScale; sy < (y+1)*aaScale; sy++ {
+					if samples.matrix[sx][sy] == (Color{}) {
+						continue
+					}
+					rgb, _ := colorToRGB(samples.matrix[sx][sy])
+					sum.R, sum.G, sum.B = sum.R+rgb.R, sum.G+rgb.G, sum.B+rgb.B
+					covered++
+				}
+			}
+			if covered == 0 {
+				continue
+			}
+			cur, err := scn.getPixel(x, y)
+			if err != nil {
+				return err
+			}
+			curRGB, _ := colorToRGB(cur)
+			avg := RGB{sum.R / covered, sum.G / covered, sum.B / covered}
+			if err = scn.drawPixel(x, y, blendRGB(curRGB, avg, float64(covered)/(aaScale*aaScale))); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// DrawAA is the Rectangle implementation of the geometry.DrawAA method
+func (r Rectangle) DrawAA(scn screen) error {
+	return drawAA(r, scn)
+}
+
+// DrawAA is the Triangle implementation of the geometry.DrawAA method
+func (t Triangle) DrawAA(scn screen) error {
+	return drawAA(t, scn)
+}
+
+// DrawAA is the Circle implementation of the geometry.DrawAA method
+func (c Circle) DrawAA(scn screen) error {
+	return drawAA(c, scn)
+}
+
+// DrawAA is the Line implementation of the geometry.DrawAA method
+func (l Line) DrawAA(scn screen) error {
+	return drawAA(l, scn)
+}
+
+// DrawAA is the Polyline implementation of the geometry.DrawAA method
+func (pl Polyline) DrawAA(scn screen) error {
+	return drawAA(pl, scn)
+}
+
+// DrawAA is the Polygon implementation of the geometry.DrawAA method
+func (pg Polygon) DrawAA(scn screen) error {
+	return drawAA(pg, scn)
+}
+
+// DrawAA is the Parallelogram implementation of the geometry.DrawAA method
+func (p Parallelogram) DrawAA(scn screen) error {
+	return drawAA(p, scn)
+}
+
+// DrawWithAA draws the shape on the display with supersampled antialiasing
+// Returns any error reported by the shape's DrawAA method
+func (d *Display) DrawWithAA(g geometry) error {
+	return g.DrawAA(d)
+}
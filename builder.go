@@ -0,0 +1,191 @@
+package main
+
+// RectangleBuilder builds a Rectangle one field at a time
+// r: The rectangle being built, the remaining fields record which parts have been set
+type RectangleBuilder struct {
+	r        Rectangle // Rectangle being built
+	hasLL    bool      // Lower-left corner set
+	hasUR    bool      // Upper-right corner set (directly or through Size)
+	hasColor bool      // Fill color set
+	w, h     int       // Size requested with Size
+	sized    bool      // Upper-right corner comes from the size
+}
+
+// CircleBuilder builds a Circle one field at a time
+type CircleBuilder struct {
+	c         Circle // Circle being built
+	hasCenter bool   // Center set
+	hasRadius bool   // Radius set
+	hasColor  bool   // Fill color set
+}
+
+// TriangleBuilder builds a Triangle one vertex at a time
+type TriangleBuilder struct {
+	t        Triangle // Triangle being built
+	vertices int      // Number of vertices set so far
+	hasColor bool     // Fill color set
+}
+
+// NewRect starts building a Rectangle
+// Example: NewRect().At(0, 0).Size(10, 10).WithColor("red").Build()
+func NewRect() *RectangleBuilder {
+	return &RectangleBuilder{r: Rectangle{Thickness: 1}}
+}
+
+// NewCirc starts building a Circle
+// Example: NewCirc().At(5, 5).Radius(3).WithColor("blue").Build()
+func NewCirc() *CircleBuilder {
+	return &CircleBuilder{c: Circle{Thickness: 1}}
+}
+
+// NewTri starts building a Triangle
+// Example: NewTri().Vertex(0, 0).Vertex(5, 5).Vertex(10, 0).WithColor("green").Build()
+func NewTri() *TriangleBuilder {
+	return &TriangleBuilder{t: Triangle{Thickness: 1}}
+}
+
+// At sets the lower-left corner of the rectangle
+func (b *RectangleBuilder) At(x, y int) *RectangleBuilder {
+	b.r.ll = Point{x, y}
+	b.hasLL = true
+	return b
+}
+
+// Size sets the width and height of the rectangle, measured from the lower-left corner
+func (b *RectangleBuilder) Size(w, h int) *RectangleBuilder {
+	b.w, b.h = w, h
+	b.hasUR, b.sized = true, true
+	return b
+}
+
+// URAt sets the upper-right corner of the rectangle, replacing any earlier Size
+func (b *RectangleBuilder) URAt(x, y int) *RectangleBuilder {
+	b.r.ur = Point{x, y}
+	b.hasUR, b.sized = true, false
+	return b
+}
+
+// WithColor sets the fill color of the rectangle by name
+func (b *RectangleBuilder) WithColor(name string) *RectangleBuilder {
+	b.r.c = NewColor(name)
+	b.hasColor = true
+	return b
+}
+
+// WithRGB sets the fill color of the rectangle to an inline RGB color
+func (b *RectangleBuilder) WithRGB(r, g, bl int) *RectangleBuilder {
+	b.r.c = NewColorRGB(r, g, bl)
+	b.hasColor = true
+	return b
+}
+
+// Build returns the finished rectangle
+// Returns errIncompleteShape if a corner, the size or the color was never set, errInvalidShape if the
+// shape is degenerate, or invalidColor if the color is unknown
+func (b *RectangleBuilder) Build() (Rectangle, error) {
+	if !b.hasLL || !b.hasUR || !b.hasColor {
+		return Rectangle{}, errIncompleteShape
+	}
+	r := b.r
+	if b.sized {
+		r.ur = Point{r.ll.x + b.w, r.ll.y + b.h}
+	}
+	if err := r.Validate(); err != nil {
+		return Rectangle{}, err
+	}
+	if colorUnknown(r.c) {
+		return Rectangle{}, invalidColor
+	}
+	return r, nil
+}
+
+// At sets the center of the circle
+func (b *CircleBuilder) At(x, y int) *CircleBuilder {
+	b.c.center = Point{x, y}
+	b.hasCenter = true
+	return b
+}
+
+// Radius sets the radius of the circle
+func (b *CircleBuilder) Radius(r int) *CircleBuilder {
+	b.c.r = r
+	b.hasRadius = true
+	return b
+}
+
+// WithColor sets the fill color of the circle by name
+func (b *CircleBuilder) WithColor(name string) *CircleBuilder {
+	b.c.c = NewColor(name)
+	b.hasColor = true
+	return b
+}
+
+// WithRGB sets the fill color of the circle to an inline RGB color
+func (b *CircleBuilder) WithRGB(r, g, bl int) *CircleBuilder {
+	b.c.c = NewColorRGB(r, g, bl)
+	b.hasColor = true
+	return b
+}
+
+// Build returns the finished circle
+// Returns errIncompleteShape if the center, radius or color was never set, errInvalidShape if the
+// shape is degenerate, or invalidColor if the color is unknown
+func (b *CircleBuilder) Build() (Circle, error) {
+	if !b.hasCenter || !b.hasRadius || !b.hasColor {
+		return Circle{}, errIncompleteShape
+	}
+	if err := b.c.Validate(); err != nil {
+		return Circle{}, err
+	}
+	if colorUnknown(b.c.c) {
+		return Circle{}, invalidColor
+	}
+	return b.c, nil
+}
+
+// Vertex sets the next vertex of the triangle
+// Vertices after the third one are ignored
+func (b *TriangleBuilder) Vertex(x, y int) *TriangleBuilder {
+	switch b.vertices {
+	case 0:
+		b.t.pt0 = Point{x, y}
+	case 1:
+		b.t.pt1 = Point{x, y}
+	case 2:
+		b.t.pt2 = Point{x, y}
+	default:
+		return b
+	}
+	b.vertices++
+	return b
+}
+
+// WithColor sets the fill color of the triangle by name
+func (b *TriangleBuilder) WithColor(name string) *TriangleBuilder {
+	b.t.c = NewColor(name)
+	b.hasColor = true
+	return b
+}
+
+// WithRGB sets the fill color of the triangle to an inline RGB color
+func (b *TriangleBuilder) WithRGB(r, g, bl int) *TriangleBuilder {
+	b.t.c = NewColorRGB(r, g, bl)
+	b.hasColor = true
+	return b
+}
+
+// Build returns the finished triangle
+// Returns errIncompleteShape if fewer than three vertices or no color were set, errInvalidShape if the
+// shape is degenerate, or invalidColor if the color is unknown
+func (b *TriangleBuilder) Build() (Triangle, error) {
+	if b.vertices < 3 || !b.hasColor {
+		return Triangle{}, errIncompleteShape
+	}
+	if err := b.t.Validate(); err != nil {
+		return Triangle{}, err
+	}
+	if colorUnknown(b.t.c) {
+		return Triangle{}, invalidColor
+	}
+	return b.t, nil
+}
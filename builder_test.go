@@ -0,0 +1,45 @@
+package main
+
+import "testing"
+
+// TestRectangleBuilder_Equivalent checks that a built rectangle equals the hand-constructed struct literal
+func TestRectangleBuilder_Equivalent(t *testing.T) {
+	got, err := NewRect().At(0, 0).Size(10, 10).WithColor("red").Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if want := (Rectangle{ll: Point{0, 0}, ur: Point{10, 10}, c: NewColor("red"), Thickness: 1}); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if got, err := NewRect().At(2, 3).URAt(8, 9).WithRGB(1, 2, 3).Build(); err != nil || got != NewRectangle(Point{2, 3}, Point{8, 9}, NewColorRGB(1, 2, 3)) {
+		t.Errorf("URAt: got %+v, %v", got, err)
+	}
+}
+
+// TestCircleAndTriangleBuilder_Equivalent checks the circle and triangle builders against their constructors
+func TestCircleAndTriangleBuilder_Equivalent(t *testing.T) {
+	c, err := NewCirc().At(5, 5).Radius(3).WithColor("blue").Build()
+	if err != nil || c != NewCircle(Point{5, 5}, 3, NewColor("blue")) {
+		t.Errorf("circle: got %+v, %v", c, err)
+	}
+	tr, err := NewTri().Vertex(0, 0).Vertex(5, 5).Vertex(10, 0).WithColor("green").Build()
+	if err != nil || tr != NewTriangle(Point{0, 0}, Point{5, 5}, Point{10, 0}, NewColor("green")) {
+		t.Errorf("triangle: got %+v, %v", tr, err)
+	}
+}
+
+// TestBuilder_Incomplete checks that a missing field returns errIncompleteShape
+func TestBuilder_Incomplete(t *testing.T) {
+	if _, err := NewRect().At(0, 0).WithColor("red").Build(); err != errIncompleteShape {
+		t.Errorf("rectangle without size: got %v, want errIncompleteShape", err)
+	}
+	if _, err := NewCirc().At(5, 5).WithColor("red").Build(); err != errIncompleteShape {
+		t.Errorf("circle without radius: got %v, want errIncompleteShape", err)
+	}
+	if _, err := NewTri().Vertex(0, 0).Vertex(5, 5).WithColor("red").Build(); err != errIncompleteShape {
+		t.Errorf("triangle with two vertices: got %v, want errIncompleteShape", err)
+	}
+	if _, err := NewRect().At(0, 0).Size(4, 4).WithColor("mauve").Build(); err != invalidColor {
+		t.Errorf("unknown color: got %v, want invalidColor", err)
+	}
+}
@@ -0,0 +1,438 @@
+package main
+
+import (
+	"math"
+	"sort"
+)
+
+// DrawGrid draws a vertical line every stepX columns and a horizontal line every stepY rows,
+// starting at column 0 and row 0 and spanning the whole display
+// Returns errInvalidStep if either step is not positive, or invalidColor for an unknown color
+func (d *Display) DrawGrid(stepX, stepY int, c Color) (err error) {
+	if stepX <= 0 || stepY <= 0 {
+		return errInvalidStep
+	}
+	if colorUnknown(c) {
+		return invalidColor
+	}
+	if d.maxX == 0 || d.maxY == 0 {
+		return nil
+	}
+
+	for x := 0; x < d.maxX; x += stepX {
+		if err = d.DrawLine(x, 0, x, d.maxY-1, c); err != nil {
+			return err
+		}
+	}
+	for y := 0; y < d.maxY; y += stepY {
+		if err = d.DrawLine(0, y, d.maxX-1, y, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DrawAxes draws one vertical and one horizontal line across the display through (originX, originY)
+// Returns errOutOfBounds if the origin is outside the display, or invalidColor for an unknown color
+func (d *Display) DrawAxes(originX, originY int, c Color) (err error) {
+	if !(Point{originX, originY}).InBounds(d) {
+		return errOutOfBounds
+	}
+	if err = d.DrawLine(originX, 0, originX, d.maxY-1, c); err != nil {
+		return err
+	}
+	return d.DrawLine(0, originY, d.maxX-1, originY, c)
+}
+
+// DrawProgressBar draws a w x h bar with its lower-left corner at (x,y), the first pct of it
+// in fillColor and the rest in bgColor
+// A horizontal bar fills from left to right, a vertical bar from the bottom up;
+// the filled length is pct times the bar length rounded to the nearest pixel
+// Returns errInvalidProgress for pct outside [0,1], errInvalidShape for an empty bar,
+// errOutOfBounds if the bar does not fit on the display, or invalidColor for an unknown color
+func (d *Display) DrawProgressBar(x, y, w, h int, pct float64, fillColor, bgColor Color, vertical bool) (err error) {
+	if pct < 0 || pct > 1 || math.IsNaN(pct) {
+		return errInvalidProgress
+	}
+	if w < 1 || h < 1 {
+		return errInvalidShape
+	}
+	if anyOutOfBounds(d, Point{x, y}, Point{x + w - 1, y + h - 1}) {
+		return errOutOfBounds
+	}
+	if colorUnknown(fillColor) || colorUnknown(bgColor) {
+		return invalidColor
+	}
+
+	length := w
+	if vertical {
+		length = h
+	}
+	filled := int(math.Round(pct * float64(length)))
+	for i := 0; i < w; i++ {
+		for j := 0; j < h; j++ {
+			pos := i
+			if vertical {
+				pos = j
+			}
+			c := bgColor
+			if pos < filled {
+				c = fillColor
+			}
+			if err = d.drawPixel(x+i, y+j, c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// PieSlice is one slice of a pie chart
+// Value: The size of the slice relative to the other slices, C: Slice color
+type PieSlice struct {
+	Value float64 // Relative size of the slice
+	C     Color   // Slice color
+}
+
+// DrawPieChart draws a filled circle centered at (cx,cy) with radius r divided into sectors,
+// one per slice, each taking value/sum of the full 360°
+// Slices are laid out counter-clockwise starting from the positive x direction
+// Returns errNoSlices for no slices, a negative value or a zero total, errInvalidShape for
+// a negative radius, errOutOfBounds if the circle does not fit on the display,
+// or invalidColor if a slice color is unknown
+func (d *Display) DrawPieChart(cx, cy, r int, slices []PieSlice) (err error) {
+	sum := 0.0
+	for _, s := range slices {
+		if s.Value < 0 || math.IsNaN(s.Value) {
+			return errNoSlices
+		}
+		sum += s.Value
+	}
+	if sum == 0 {
+		return errNoSlices
+	}
+	for _, s := range slices {
+		if colorUnknown(s.C) {
+			return invalidColor
+		}
+	}
+	if r < 0 {
+		return errInvalidShape
+	}
+	center := Point{cx, cy}
+	if checksBounds(d) && outOfBoundsCircle(center, r, d) {
+		return errOutOfBounds
+	}
+
+	// ends[i] is the angle in degrees at which slice i stops
+	ends := make([]float64, len(slices))
+	angle := 0.0
+	for i, s := range slices {
+		angle += s.Value / sum * 360
+		ends[i] = angle
+	}
+	ends[len(ends)-1] = 360
+
+	for y := cy - r; y <= cy+r; y++ {
+		for x := cx - r; x <= cx+r; x++ {
+			if !insideCircle(center, Point{x, y}, float64(r)) {
+				continue
+			}
+			a := math.Atan2(float64(y-cy), float64(x-cx)) * 180 / math.Pi
+			if a < 0 {
+				a += 360
+			}
+			i := 0
+			for i < len(ends)-1 && a >= ends[i] {
+				i++
+			}
+			if err = d.drawPixel(x, y, slices[i].C); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// DrawCrossHair draws a plus sign centered at (cx,cy) whose horizontal and vertical lines are size pixels long
+// Each arm reaches size/2 pixels from the center; for an even size the extra pixel is on the low x and y side
+// Pixels within gapRadius of the center are left out; with no gap the center pixel is drawn
+// Returns errInvalidGap if size < 1, gapRadius is negative or a gap is not smaller than the size/2 arm length,
+// errOutOfBounds if the crosshair does not fit on the display, or invalidColor for an unknown color
+func (d *Display) DrawCrossHair(cx, cy, size, gapRadius int, c Color) (err error) {
+	lo := -(size / 2)
+	hi := lo + size - 1
+	if size < 1 || gapRadius < 0 || gapRadius > 0 && gapRadius >= size/2 {
+		return errInvalidGap
+	}
+	if anyOutOfBounds(d, Point{cx + lo, cy}, Point{cx + hi, cy}, Point{cx, cy + lo}, Point{cx, cy + hi}) {
+		return errOutOfBounds
+	}
+	if colorUnknown(c) {
+		return invalidColor
+	}
+	for o := lo; o <= hi; o++ {
+		if gapRadius > 0 && abs(o) <= gapRadius {
+			continue
+		}
+		if err = d.drawPixel(cx+o, cy, c); err != nil {
+			return err
+		}
+		if err = d.drawPixel(cx, cy+o, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Predefined palettes for DrawHeatmap, ordered from the lowest to the highest value
+// PaletteHot: Black through red and yellow to white
+// PaletteViridis: Dark purple through blue and green to yellow
+// PaletteCool: Cyan to magenta
+var PaletteHot = []Color{
+	NewColorRGB(0x00, 0x00, 0x00),
+	NewColorRGB(0x80, 0x00, 0x00),
+	NewColorRGB(0xff, 0x00, 0x00),
+	NewColorRGB(0xff, 0x80, 0x00),
+	NewColorRGB(0xff, 0xff, 0x00),
+	NewColorRGB(0xff, 0xff, 0xff),
+}
+var PaletteViridis = []Color{
+	NewColorRGB(0x44, 0x01, 0x54),
+	NewColorRGB(0x3b, 0x52, 0x8b),
+	NewColorRGB(0x21, 0x91, 0x8c),
+	NewColorRGB(0x5e, 0xc9, 0x62),
+	NewColorRGB(0xfd, 0xe7, 0x25),
+}
+var PaletteCool = []Color{
+	NewColorRGB(0x00, 0xff, 0xff),
+	NewColorRGB(0x40, 0xbf, 0xff),
+	NewColorRGB(0x80, 0x80, 0xff),
+	NewColorRGB(0xbf, 0x40, 0xff),
+	NewColorRGB(0xff, 0x00, 0xff),
+}
+
+// matchesData returns true if data has one value for every pixel, indexed as data[x][y]
+func (d *Display) matchesData(data [][]float64) bool {
+	if len(data) != d.maxX {
+		return false
+	}
+	for _, column := range data {
+		if len(column) != d.maxY {
+			return false
+		}
+	}
+	return true
+}
+
+// DrawHeatmap colors every pixel (x,y) by the value data[x][y]
+// The values are scaled linearly so that the smallest maps to the first palette color and the
+// largest to the last; if every value is the same, all pixels get the first color
+// NaN values leave their pixel unchanged
+// Returns errDimensionMismatch if data is not maxX x maxY, errEmptyPalette for an empty palette,
+// or invalidColor if a palette color is unknown
+func (d *Display) DrawHeatmap(data [][]float64, palette []Color) error {
+	if !d.matchesData(data) {
+		return errDimensionMismatch
+	}
+	if len(palette) == 0 {
+		return errEmptyPalette
+	}
+	for _, c := range palette {
+		if colorUnknown(c) {
+			return invalidColor
+		}
+	}
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, column := range data {
+		for _, v := range column {
+			if !math.IsNaN(v) {
+				lo, hi = math.Min(lo, v), math.Max(hi, v)
+			}
+		}
+	}
+
+	for x, column := range data {
+		for y, v := range column {
+			if math.IsNaN(v) {
+				continue
+			}
+			i := 0
+			if hi > lo {
+				i = int(math.Round((v - lo) / (hi - lo) * float64(len(palette)-1)))
+			}
+			d.matrix[x][y] = palette[i]
+		}
+	}
+	return nil
+}
+
+// DrawContour draws the iso-line where the data field crosses level
+// Every cell between four neighboring pixels whose corners lie on both sides of level is
+// crossing; the crossing points on its edges are found by linear interpolation and the
+// pixel nearest to their average is colored
+// Returns errDimensionMismatch if data is not maxX x maxY or invalidColor for an unknown color
+func (d *Display) DrawContour(data [][]float64, level float64, c Color) error {
+	if !d.matchesData(data) {
+		return errDimensionMismatch
+	}
+	if colorUnknown(c) {
+		return invalidColor
+	}
+
+	// crossing returns where the edge from a to b crosses level, as a fraction of its length
+	crossing := func(a, b float64) (float64, bool) {
+		if (a < level) == (b < level) {
+			return 0, false
+		}
+		return (level - a) / (b - a), true
+	}
+
+	for x := 0; x+1 < d.maxX; x++ {
+		for y := 0; y+1 < d.maxY; y++ {
+			// Corners counter-clockwise from (x,y), and the edges between them
+			corners := [4]Point{{x, y}, {x + 1, y}, {x + 1, y + 1}, {x, y + 1}}
+			var sx, sy float64
+			n := 0
+			for i, p := range corners {
+				q := corners[(i+1)%4]
+				t, ok := crossing(data[p.x][p.y], data[q.x][q.y])
+				if !ok {
+					continue
+				}
+				sx += float64(p.x) + t*float64(q.x-p.x)
+				sy += float64(p.y) + t*float64(q.y-p.y)
+				n++
+			}
+			if n == 0 {
+				continue
+			}
+			d.matrix[int(math.Round(sx/float64(n)))][int(math.Round(sy/float64(n)))] = c
+		}
+	}
+	return nil
+}
+
+// DrawContours draws one iso-line per level, levels[i] in colors[i]
+// Returns errDimensionMismatch if data is not maxX x maxY or there is not one color per level,
+// or invalidColor for an unknown color
+func (d *Display) DrawContours(data [][]float64, levels []float64, colors []Color) error {
+	if len(levels) != len(colors) {
+		return errDimensionMismatch
+	}
+	for i, level := range levels {
+		if err := d.DrawContour(data, level, colors[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DrawHistogramBar draws a bar chart of data in the w x h region with its lower-left corner at (x,y)
+// Keys are laid out left to right in alphabetical order, each in a bar of equal width (the last bar
+// takes the leftover pixels) whose height is its value relative to the largest value
+// Bars are colored by labelColors; keys without a color are black and values <= 0 draw no bar
+// Returns errInvalidShape for an empty region, errInvalidSplit if the region is narrower than the
+// number of keys, errDimensionMismatch if the region extends outside the display, or invalidColor if a
+// label color is unknown
+func (d *Display) DrawHistogramBar(data map[string]int, x, y, w, h int, labelColors map[string]Color) (err error) {
+	if w < 1 || h < 1 {
+		return errInvalidShape
+	}
+	if anyOutOfBounds(d, Point{x, y}, Point{x + w - 1, y + h - 1}) {
+		return errDimensionMismatch
+	}
+	for _, c := range labelColors {
+		if colorUnknown(c) {
+			return invalidColor
+		}
+	}
+	if len(data) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(data))
+	largest := 0
+	for k, v := range data {
+		keys = append(keys, k)
+		largest = max(largest, v)
+	}
+	sort.Strings(keys)
+	bars, err := Rectangle{ll: Point{x, y}, ur: Point{x + w, y + h}, Thickness: 1}.SplitH(len(keys))
+	if err != nil {
+		return err
+	}
+
+	for i, k := range keys {
+		c, ok := labelColors[k]
+		if !ok {
+			c = NewColor("black")
+		}
+		height := 0
+		if largest > 0 && data[k] > 0 {
+			height = int(math.Round(float64(data[k]) / float64(largest) * float64(h)))
+		}
+		for bx := bars[i].ll.x; bx < bars[i].ur.x; bx++ {
+			for by := y; by < y+height; by++ {
+				if err = d.drawPixel(bx, by, c); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// GraphNode is a node of a graph drawn by DrawConnectedGraph
+// Pos: Center of the node, Label: Name of the node (not drawn), Color: Fill color of the node
+type GraphNode struct {
+	Pos   Point  // Center of the node
+	Label string // Name of the node
+	Color Color  // Fill color of the node
+}
+
+// GraphEdge joins two nodes of a graph drawn by DrawConnectedGraph
+// From, To: Indices of the nodes in the node list
+type GraphEdge struct {
+	From, To int // Indices of the joined nodes
+}
+
+// DrawConnectedGraph draws every edge as a black line between the centers of its nodes and then
+// every node on top as a filled circle of radius nodeRadius in its own color
+// Nothing is drawn unless every node and edge is valid; labels are not drawn
+// Returns errInvalidShape for nodeRadius < 1, errInvalidEdge for an edge that refers to a missing
+// node, errOutOfBounds if a node does not fit on the display, or invalidColor for an unknown color
+func (d *Display) DrawConnectedGraph(nodes []GraphNode, edges []GraphEdge, nodeRadius int) (err error) {
+	circles := make([]Circle, len(nodes))
+	for i, n := range nodes {
+		circles[i] = Circle{center: n.Pos, r: nodeRadius, c: n.Color, Thickness: 1}
+		if err = circles[i].Validate(); err != nil {
+			return err
+		}
+		if checksBounds(d) && outOfBoundsCircle(n.Pos, nodeRadius, d) {
+			return errOutOfBounds
+		}
+		if colorUnknown(n.Color) {
+			return invalidColor
+		}
+	}
+	for _, e := range edges {
+		if e.From < 0 || e.From >= len(nodes) || e.To < 0 || e.To >= len(nodes) {
+			return errInvalidEdge
+		}
+	}
+
+	for _, e := range edges {
+		from, to := nodes[e.From].Pos, nodes[e.To].Pos
+		if err = d.DrawLine(from.x, from.y, to.x, to.y, NewColor("black")); err != nil {
+			return err
+		}
+	}
+	for _, c := range circles {
+		if err = c.DrawOn(d, DrawFill); err != nil {
+			return err
+		}
+	}
+	return nil
+}
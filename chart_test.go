@@ -0,0 +1,264 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// TestDrawGrid_Step10 checks that a 100x100 grid with steps of 10 colors every 10th row and
+// column across the whole display and nothing else
+func TestDrawGrid_Step10(t *testing.T) {
+	red := NewColor("red")
+	d := newDisplay(100, 100)
+	if err := d.DrawGrid(10, 10, red); err != nil {
+		t.Fatalf("DrawGrid: %v", err)
+	}
+	for i := 0; i < 100; i += 10 {
+		if !d.AssertColumnUniform(i, red) {
+			t.Errorf("column %d is not a grid line", i)
+		}
+		for _, err := range d.AssertRegion(0, i, 99, i, red) {
+			t.Error(err)
+		}
+	}
+	if got := d.Count(red); got != 1900 {
+		t.Errorf("got %d grid pixels, want 1900", got)
+	}
+	if err := d.DrawGrid(0, 10, red); err != errInvalidStep {
+		t.Errorf("step 0: got %v, want errInvalidStep", err)
+	}
+}
+
+// TestDrawAxes_Origin checks that the axes cross at the origin and span the display
+func TestDrawAxes_Origin(t *testing.T) {
+	blue := NewColor("blue")
+	d := newDisplay(40, 30)
+	if err := d.DrawAxes(12, 7, blue); err != nil {
+		t.Fatalf("DrawAxes: %v", err)
+	}
+	if !d.AssertColumnUniform(12, blue) {
+		t.Error("column 12 is not the y axis")
+	}
+	for _, err := range d.AssertRegion(0, 7, 39, 7, blue) {
+		t.Error(err)
+	}
+	if got := d.Count(blue); got != 40+30-1 {
+		t.Errorf("got %d axis pixels, want %d", got, 40+30-1)
+	}
+	if err := d.DrawAxes(40, 7, blue); err != errOutOfBounds {
+		t.Errorf("origin off the display: got %v, want errOutOfBounds", err)
+	}
+}
+
+// TestDrawProgressBar_Half checks that pct=0.5 on a 100 pixel bar fills exactly 50 pixels,
+// from the left for a horizontal bar and from the bottom for a vertical one
+func TestDrawProgressBar_Half(t *testing.T) {
+	green, gray := NewColor("green"), NewColor("white")
+	d := newDisplay(100, 100)
+	if err := d.DrawProgressBar(0, 0, 100, 1, 0.5, green, gray, false); err != nil {
+		t.Fatalf("DrawProgressBar: %v", err)
+	}
+	if got := d.Count(green); got != 50 {
+		t.Errorf("horizontal: got %d filled pixels, want 50", got)
+	}
+	for _, err := range d.AssertRegion(0, 0, 49, 0, green) {
+		t.Errorf("horizontal: %v", err)
+	}
+
+	d = newDisplay(100, 100)
+	if err := d.DrawProgressBar(10, 0, 1, 100, 0.5, green, gray, true); err != nil {
+		t.Fatalf("DrawProgressBar: %v", err)
+	}
+	if got := d.Count(green); got != 50 {
+		t.Errorf("vertical: got %d filled pixels, want 50", got)
+	}
+	for _, err := range d.AssertRegion(10, 0, 10, 49, green) {
+		t.Errorf("vertical: %v", err)
+	}
+	if err := d.DrawProgressBar(0, 0, 10, 1, 1.5, green, gray, false); err != errInvalidProgress {
+		t.Errorf("pct 1.5: got %v, want errInvalidProgress", err)
+	}
+}
+
+// TestDrawPieChart_TwoEqualSlices checks that two equal slices each take 180°: the first
+// covers the half above the center row and the second the half below
+func TestDrawPieChart_TwoEqualSlices(t *testing.T) {
+	red, blue := NewColor("red"), NewColor("blue")
+	d := newDisplay(41, 41)
+	if err := d.DrawPieChart(20, 20, 15, []PieSlice{{1, red}, {1, blue}}); err != nil {
+		t.Fatalf("DrawPieChart: %v", err)
+	}
+	d.PixelScan(func(x, y int, c Color) {
+		if y > 20 && c == blue || y < 20 && c == red {
+			t.Errorf("pixel (%d,%d) is in the wrong half", x, y)
+		}
+	})
+	// The center row splits at the center, which starts the first slice
+	if reds, blues := d.Count(red), d.Count(blue); reds != blues+1 {
+		t.Errorf("got %d red and %d blue pixels, want one more red for the center", reds, blues)
+	}
+	if err := d.DrawPieChart(20, 20, 15, nil); err != errNoSlices {
+		t.Errorf("no slices: got %v, want errNoSlices", err)
+	}
+}
+
+// TestDrawHeatmap_Corners checks that the smallest value gets the first palette color and the
+// largest the last, and that data of the wrong size is rejected
+func TestDrawHeatmap_Corners(t *testing.T) {
+	d := newDisplay(10, 10)
+	data := make([][]float64, 10)
+	for x := range data {
+		data[x] = make([]float64, 10)
+		for y := range data[x] {
+			data[x][y] = float64(x+y) / 18
+		}
+	}
+	for name, palette := range map[string][]Color{"hot": PaletteHot, "viridis": PaletteViridis, "cool": PaletteCool} {
+		if err := d.DrawHeatmap(data, palette); err != nil {
+			t.Fatalf("%s: DrawHeatmap: %v", name, err)
+		}
+		if err := d.ComparePixel(0, 0, palette[0]); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		if err := d.ComparePixel(9, 9, palette[len(palette)-1]); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+	if err := d.DrawHeatmap(data[:9], PaletteHot); err != errDimensionMismatch {
+		t.Errorf("9 columns: got %v, want errDimensionMismatch", err)
+	}
+}
+
+// TestDrawCrossHair_Gap checks that each line is size pixels long and centered, that the center is
+// left out with a gap and that the pixels just past the gap are drawn
+func TestDrawCrossHair_Gap(t *testing.T) {
+	red, white := NewColor("red"), NewColor("white")
+	d := newDisplay(41, 41)
+	if err := d.DrawCrossHair(20, 20, 21, 3, red); err != nil {
+		t.Fatalf("DrawCrossHair: %v", err)
+	}
+	if err := d.ComparePixel(20, 20, white); err != nil {
+		t.Errorf("center: %v", err)
+	}
+	for _, p := range []Point{{24, 20}, {16, 20}, {20, 24}, {20, 16}, {30, 20}, {10, 20}, {20, 30}, {20, 10}} {
+		if err := d.ComparePixel(p.x, p.y, red); err != nil {
+			t.Error(err)
+		}
+	}
+	for _, p := range []Point{{23, 20}, {31, 20}, {9, 20}, {20, 31}, {20, 9}} {
+		if err := d.ComparePixel(p.x, p.y, white); err != nil {
+			t.Error(err)
+		}
+	}
+	// 21 pixels per line less the 7 pixels of the gap
+	if got := d.Count(red); got != 2*(21-7) {
+		t.Errorf("got %d red pixels, want %d", got, 2*(21-7))
+	}
+
+	d = newDisplay(41, 41)
+	if err := d.DrawCrossHair(20, 20, 10, 0, red); err != nil {
+		t.Fatalf("DrawCrossHair: %v", err)
+	}
+	if got := d.CountInRegion(red, 0, 20, 40, 20); got != 10 {
+		t.Errorf("horizontal line is %d pixels long, want 10", got)
+	}
+	if err := d.DrawCrossHair(20, 20, 10, 5, red); err != errInvalidGap {
+		t.Errorf("gap 5 on size 10: got %v, want errInvalidGap", err)
+	}
+}
+
+// radialField returns a size x size field whose value is the distance from the center pixel
+func radialField(size int) [][]float64 {
+	c := Point{size / 2, size / 2}
+	data := make([][]float64, size)
+	for x := range data {
+		data[x] = make([]float64, size)
+		for y := range data[x] {
+			data[x][y] = (Point{x, y}).Distance(c)
+		}
+	}
+	return data
+}
+
+// TestDrawContours_ConcentricCircles checks that the contours of a radially symmetric field are
+// circles around the center at the radius of their level
+func TestDrawContours_ConcentricCircles(t *testing.T) {
+	red, blue := NewColor("red"), NewColor("blue")
+	d := newDisplay(61, 61)
+	if err := d.DrawContours(radialField(61), []float64{10, 20}, []Color{red, blue}); err != nil {
+		t.Fatalf("DrawContours: %v", err)
+	}
+	for c, r := range map[Color]float64{red: 10, blue: 20} {
+		n := 0
+		d.PixelScan(func(x, y int, got Color) {
+			if got != c {
+				return
+			}
+			n++
+			if dist := (Point{x, y}).Distance(Point{30, 30}); math.Abs(dist-r) > 1 {
+				t.Errorf("pixel (%d,%d) of the %v contour is %.2f from the center", x, y, r, dist)
+			}
+		})
+		// One pixel per crossing cell goes around the whole circumference
+		if n < int(2*math.Pi*r) {
+			t.Errorf("the %v contour has %d pixels, want at least %d", r, n, int(2*math.Pi*r))
+		}
+	}
+	if err := d.DrawContour(radialField(60), 10, red); err != errDimensionMismatch {
+		t.Errorf("60x60 data: got %v, want errDimensionMismatch", err)
+	}
+}
+
+// TestDrawHistogramBar_EqualValues checks that two equal values draw two bars of equal height
+// filling the region, and that a region outside the display is rejected
+func TestDrawHistogramBar_EqualValues(t *testing.T) {
+	red, blue := NewColor("red"), NewColor("blue")
+	d := newDisplay(30, 30)
+	err := d.DrawHistogramBar(map[string]int{"a": 7, "b": 7}, 5, 2, 20, 10, map[string]Color{"a": red, "b": blue})
+	if err != nil {
+		t.Fatalf("DrawHistogramBar: %v", err)
+	}
+	for _, err := range append(d.AssertRegion(5, 2, 14, 11, red), d.AssertRegion(15, 2, 24, 11, blue)...) {
+		t.Error(err)
+	}
+	if reds, blues := d.Count(red), d.Count(blue); reds != 100 || blues != 100 {
+		t.Errorf("got bars of %d and %d pixels, want 100 each", reds, blues)
+	}
+	if err := d.DrawHistogramBar(map[string]int{"a": 1}, 25, 0, 10, 10, nil); err != errDimensionMismatch {
+		t.Errorf("region past the edge: got %v, want errDimensionMismatch", err)
+	}
+}
+
+// TestDrawConnectedGraph_Triangle checks a graph of 3 nodes and 3 edges: each node is a filled
+// circle in its color and each edge a black line between the circles
+func TestDrawConnectedGraph_Triangle(t *testing.T) {
+	black := NewColor("black")
+	nodes := []GraphNode{
+		{Point{10, 10}, "a", NewColor("red")},
+		{Point{50, 10}, "b", NewColor("green")},
+		{Point{30, 40}, "c", NewColor("blue")},
+	}
+	edges := []GraphEdge{{0, 1}, {1, 2}, {2, 0}}
+	d := newDisplay(61, 51)
+	if err := d.DrawConnectedGraph(nodes, edges, 3); err != nil {
+		t.Fatalf("DrawConnectedGraph: %v", err)
+	}
+	circle := newDisplay(61, 51)
+	if err := NewCircle(Point{10, 10}, 3, black).DrawOn(circle, DrawFill); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+	for _, n := range nodes {
+		if got, want := d.Count(n.Color), circle.Count(black); got != want {
+			t.Errorf("node %s has %d pixels, want a circle of %d", n.Label, got, want)
+		}
+	}
+	// Midpoints of the three edges
+	for _, p := range []Point{{30, 10}, {40, 25}, {20, 25}} {
+		if err := d.ComparePixel(p.x, p.y, black); err != nil {
+			t.Errorf("edge: %v", err)
+		}
+	}
+	if err := d.DrawConnectedGraph(nodes, []GraphEdge{{0, 3}}, 3); err != errInvalidEdge {
+		t.Errorf("edge to a missing node: got %v, want errInvalidEdge", err)
+	}
+}
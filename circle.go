@@ -0,0 +1,65 @@
+package main
+
+// Distance returns the gap between the circumferences of two circles
+// The result is negative when the circles overlap and 0 when they are tangent
+// Circle.Intersects, the geometry.Intersects implementation, reports the same as Distance(other) <= 0
+// for two circles, so there is no separate Circle-only Intersects
+func (c Circle) Distance(other Circle) float64 {
+	return c.center.Distance(other.center) - float64(c.r+other.r)
+}
+
+// ContainsCircle returns true if the other circle lies entirely inside this one
+// Touching the circumference from the inside still counts as contained
+// It is not named Contains because Circle.Contains already implements geometry.Contains for points
+func (c Circle) ContainsCircle(other Circle) bool {
+	return c.center.Distance(other.center)+float64(other.r) <= float64(c.r)
+}
+
+// drawOval draws the ellipse that fits the box of w x h pixels with its top-left corner at (x,y)
+// A pixel belongs to the ellipse if its center lies inside it; when outline is set only the
+// pixels of the ellipse with a 4-neighbor outside it are drawn
+func (d *Display) drawOval(x, y, w, h int, c Color, outline bool) (err error) {
+	if w < 1 || h < 1 {
+		return errInvalidShape
+	}
+	if anyOutOfBounds(d, Point{x, y}, Point{x + w - 1, y + h - 1}) {
+		return errOutOfBounds
+	}
+	if colorUnknown(c) {
+		return invalidColor
+	}
+
+	cx, cy := float64(x)+float64(w-1)/2, float64(y)+float64(h-1)/2
+	rx, ry := float64(w)/2, float64(h)/2
+	inside := func(px, py int) bool {
+		dx, dy := (float64(px)-cx)/rx, (float64(py)-cy)/ry
+		return dx*dx+dy*dy <= 1
+	}
+	for px := x; px < x+w; px++ {
+		for py := y; py < y+h; py++ {
+			if !inside(px, py) {
+				continue
+			}
+			if outline && inside(px-1, py) && inside(px+1, py) && inside(px, py-1) && inside(px, py+1) {
+				continue
+			}
+			if err = d.drawPixel(px, py, c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// DrawOval draws the outline of the ellipse that fits the box of w x h pixels with its
+// top-left corner at (x,y), like Graphics.drawOval in other toolkits
+// Returns errInvalidShape for w or h < 1, errOutOfBounds if the box does not fit on the display,
+// or invalidColor for an unknown color
+func (d *Display) DrawOval(x, y, w, h int, c Color) error {
+	return d.drawOval(x, y, w, h, c, true)
+}
+
+// FillOval is DrawOval filling the ellipse instead of outlining it
+func (d *Display) FillOval(x, y, w, h int, c Color) error {
+	return d.drawOval(x, y, w, h, c, false)
+}
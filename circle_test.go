@@ -0,0 +1,183 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// TestCircle_DrawBoth checks that DrawBoth fills the interior and draws the outline on top of it
+func TestCircle_DrawBoth(t *testing.T) {
+	red, blue := NewColor("red"), NewColor("blue")
+	c := NewCircle(Point{10, 10}, 6, red).WithStroke(blue)
+
+	d := newDisplay(21, 21)
+	if err := d.DrawWithMode(c, DrawBoth); err != nil {
+		t.Fatalf("DrawWithMode: %v", err)
+	}
+	if err := d.ComparePixel(10, 10, red); err != nil {
+		t.Error(err)
+	}
+	for _, p := range []Point{{16, 10}, {4, 10}, {10, 16}, {10, 4}} {
+		if err := d.ComparePixel(p.x, p.y, blue); err != nil {
+			t.Error(err)
+		}
+	}
+
+	// Without a stroke, DrawBoth covers exactly the pixels of DrawFill and DrawOutline together
+	plain := NewCircle(Point{10, 10}, 6, red)
+	layers := map[DrawMode]*Display{}
+	for _, mode := range []DrawMode{DrawFill, DrawOutline, DrawBoth} {
+		layers[mode] = newDisplay(21, 21)
+		if err := layers[mode].DrawWithMode(plain, mode); err != nil {
+			t.Fatalf("DrawWithMode(%v): %v", mode, err)
+		}
+	}
+	if err := layers[DrawOutline].ComparePixel(10, 10, NewColor("white")); err != nil {
+		t.Error(err)
+	}
+	for x := 0; x < 21; x++ {
+		for y := 0; y < 21; y++ {
+			union := layers[DrawFill].matrix[x][y] == red || layers[DrawOutline].matrix[x][y] == red
+			if both := layers[DrawBoth].matrix[x][y] == red; both != union {
+				t.Errorf("pixel (%d,%d): DrawBoth %v, fill or outline %v", x, y, both, union)
+			}
+		}
+	}
+}
+
+// TestDrawCircleOutline_MatchesCircle checks that the midpoint outline and the scan fill draw the
+// same pixels as a Circle in DrawOutline and DrawFill mode, for several radii
+func TestDrawCircleOutline_MatchesCircle(t *testing.T) {
+	red := NewColor("red")
+	for _, r := range []int{1, 2, 5, 9, 14} {
+		for _, mode := range []DrawMode{DrawOutline, DrawFill} {
+			want := newDisplay(31, 31)
+			if err := NewCircle(Point{15, 15}, r, red).DrawOn(want, mode); err != nil {
+				t.Fatalf("r=%d: Circle.DrawOn: %v", r, err)
+			}
+			got := newDisplay(31, 31)
+			draw := got.DrawCircleOutline
+			if mode == DrawFill {
+				draw = got.DrawCircleFilled
+			}
+			if err := draw(15, 15, r, red); err != nil {
+				t.Fatalf("r=%d: %v", r, err)
+			}
+			if !got.Equal(want) {
+				t.Errorf("r=%d, mode %v: pixels differ from the Circle", r, mode)
+			}
+		}
+	}
+}
+
+// TestCircle_Predicates checks Distance, Intersects and ContainsCircle for tangent, contained
+// and separate circles
+func TestCircle_Predicates(t *testing.T) {
+	red := NewColor("red")
+	big := NewCircle(Point{30, 30}, 10, red)
+	for _, tc := range []struct {
+		name       string
+		other      Circle
+		distance   float64
+		intersects bool
+		contains   bool
+	}{
+		{"tangent outside", NewCircle(Point{45, 30}, 5, red), 0, true, false},
+		{"tangent inside", NewCircle(Point{37, 30}, 3, red), -6, true, true},
+		{"contained", NewCircle(Point{32, 30}, 3, red), -11, true, true},
+		{"overlapping", NewCircle(Point{40, 30}, 5, red), -5, true, false},
+		{"separate", NewCircle(Point{60, 30}, 5, red), 15, false, false},
+	} {
+		if got := big.Distance(tc.other); got != tc.distance {
+			t.Errorf("%s: Distance = %v, want %v", tc.name, got, tc.distance)
+		}
+		if got := big.Intersects(tc.other); got != tc.intersects {
+			t.Errorf("%s: Intersects = %v, want %v", tc.name, got, tc.intersects)
+		}
+		if got := big.ContainsCircle(tc.other); got != tc.contains {
+			t.Errorf("%s: ContainsCircle = %v, want %v", tc.name, got, tc.contains)
+		}
+		if tc.other.ContainsCircle(big) {
+			t.Errorf("%s: the smaller circle contains the larger one", tc.name)
+		}
+	}
+}
+
+// TestCircle_DrawAAEdge checks that a supersampled circle keeps a solid interior and gets pixels
+// between black and white along its boundary, where DrawOn draws only black ones
+func TestCircle_DrawAAEdge(t *testing.T) {
+	black := NewColor("black")
+	c := NewCircle(Point{20, 20}, 15, black)
+	aa, plain := newDisplay(41, 41), newDisplay(41, 41)
+	if err := aa.DrawWithAA(c); err != nil {
+		t.Fatalf("DrawWithAA: %v", err)
+	}
+	if err := c.DrawOn(plain, DrawDefault); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+
+	edge := func(d *Display) (partial, inside int) {
+		d.PixelScan(func(x, y int, col Color) {
+			rgb, _ := colorToRGB(col)
+			if rgb.R > 0 && rgb.R < 255 {
+				partial++
+				if dist := (Point{x, y}).Distance(Point{20, 20}); dist < 13 || dist > 17 {
+					t.Errorf("intermediate pixel (%d,%d) is %.2f from the center, away from the edge", x, y, dist)
+				}
+			}
+			if rgb.R == 0 {
+				inside++
+			}
+		})
+		return partial, inside
+	}
+	if partial, inside := edge(aa); partial < 40 || inside == 0 {
+		t.Errorf("DrawWithAA drew %d intermediate and %d black pixels, want the edge shaded and the inside solid", partial, inside)
+	}
+	if partial, _ := edge(plain); partial != 0 {
+		t.Errorf("DrawOn drew %d intermediate pixels, want none", partial)
+	}
+	if err := aa.ComparePixel(20, 20, black); err != nil {
+		t.Errorf("center: %v", err)
+	}
+}
+
+// TestFillOval_Box checks that a filled oval stays inside its box, touches all four sides and covers
+// about the area of the ellipse, and that DrawOval only draws the boundary of the same pixels
+func TestFillOval_Box(t *testing.T) {
+	red := NewColor("red")
+	filled, outlined := newDisplay(30, 20), newDisplay(30, 20)
+	if err := filled.FillOval(2, 3, 20, 10, red); err != nil {
+		t.Fatalf("FillOval: %v", err)
+	}
+	if err := outlined.DrawOval(2, 3, 20, 10, red); err != nil {
+		t.Fatalf("DrawOval: %v", err)
+	}
+
+	n := filled.Count(red)
+	if got := filled.CountInRegion(red, 2, 3, 21, 12); got != n {
+		t.Errorf("%d of the %d oval pixels are outside the box", n-got, n)
+	}
+	for _, p := range []Point{{2, 7}, {21, 7}, {11, 3}, {11, 12}} {
+		if err := filled.ComparePixel(p.x, p.y, red); err != nil {
+			t.Errorf("side of the box: %v", err)
+		}
+	}
+	if want := math.Pi * 10 * 5; math.Abs(float64(n)-want) > 20 {
+		t.Errorf("got %d pixels, want about %.0f", n, want)
+	}
+	outlined.PixelScan(func(x, y int, c Color) {
+		if c == red && filled.matrix[x][y] != red {
+			t.Errorf("outline pixel (%d,%d) is not part of the filled oval", x, y)
+		}
+	})
+	if err := outlined.ComparePixel(11, 7, NewColor("white")); err != nil {
+		t.Errorf("inside of the outline: %v", err)
+	}
+	if err := filled.FillOval(2, 3, 0, 10, red); err != errInvalidShape {
+		t.Errorf("zero width: got %v, want errInvalidShape", err)
+	}
+	if err := filled.FillOval(20, 3, 20, 10, red); err != errOutOfBounds {
+		t.Errorf("box past the edge: got %v, want errOutOfBounds", err)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import "testing"
+
+// TestColor_Accessors checks Name, RGB, IsValid and that SetName rejects unknown names
+func TestColor_Accessors(t *testing.T) {
+	c := NewColor("red")
+	if c.Name() != "red" || !c.IsValid() {
+		t.Errorf("got name %q, valid %v", c.Name(), c.IsValid())
+	}
+	if rgb, err := c.RGB(); err != nil || rgb != (RGB{255, 0, 0}) {
+		t.Errorf("RGB: got %v, %v", rgb, err)
+	}
+	if err := c.SetName("mauve"); err != invalidColor || c.Name() != "red" {
+		t.Errorf("SetName(mauve): got %v and name %q, want invalidColor and red", err, c.Name())
+	}
+	if err := c.SetName("#102030"); err != nil || c.Name() != "#102030" {
+		t.Errorf("SetName(#102030): got %v and name %q", err, c.Name())
+	}
+	if (Color{}).IsValid() {
+		t.Error("the zero Color is valid")
+	}
+}
+
+// TestAddColorNamed_Register checks that a new color can be registered and looked up once,
+// and that built-in names, inline names and out of range components are rejected
+func TestAddColorNamed_Register(t *testing.T) {
+	t.Cleanup(func() { delete(ColorMap, "teal") })
+	if err := AddColorNamed("teal", 0, 128, 128); err != nil {
+		t.Fatalf("AddColorNamed: %v", err)
+	}
+	if rgb, ok := GetColorNamed("teal"); !ok || rgb != (RGB{0, 128, 128}) {
+		t.Errorf("GetColorNamed: got %v, %v", rgb, ok)
+	}
+	if !NewColor("teal").IsValid() {
+		t.Error("the new color is not valid")
+	}
+	for name, want := range map[string]error{"teal": errColorExists, "red": errColorExists, "#abc": invalidColor, "": invalidColor} {
+		if err := AddColorNamed(name, 1, 2, 3); err != want {
+			t.Errorf("AddColorNamed(%q): got %v, want %v", name, err, want)
+		}
+	}
+	if err := AddColorNamed("toobright", 0, 256, 0); err != errInvalidRGB {
+		t.Errorf("component 256: got %v, want errInvalidRGB", err)
+	}
+	if _, ok := GetColorNamed("mauve"); ok {
+		t.Error("GetColorNamed found an unregistered color")
+	}
+
+	names := ColorNames()
+	for i := 1; i < len(names); i++ {
+		if names[i-1] >= names[i] {
+			t.Fatalf("ColorNames is not sorted: %q before %q", names[i-1], names[i])
+		}
+	}
+	if len(names) != len(ColorMap) {
+		t.Errorf("got %d names for %d colors", len(names), len(ColorMap))
+	}
+}
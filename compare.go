@@ -0,0 +1,141 @@
+package main
+
+import "fmt"
+
+// newDisplay allocates and initializes a display with the given dimensions
+func newDisplay(x, y int) *Display {
+	d := &Display{}
+	d.initialize(x, y)
+	return d
+}
+
+// abs returns the absolute value of an integer
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// Diff returns a new display holding the per-channel absolute difference of the two displays
+// Identical pixels come out black (0,0,0); the brighter a pixel, the larger the difference
+// Returns errDimensionMismatch if the displays are not the same size
+func (d *Display) Diff(other *Display) (*Display, error) {
+	if d.maxX != other.maxX || d.maxY != other.maxY {
+		return nil, errDimensionMismatch
+	}
+
+	out := newDisplay(d.maxX, d.maxY)
+	for x := 0; x < d.maxX; x++ {
+		for y := 0; y < d.maxY; y++ {
+			a, err := colorToRGB(d.matrix[x][y])
+			if err != nil {
+				return nil, err
+			}
+			b, err := colorToRGB(other.matrix[x][y])
+			if err != nil {
+				return nil, err
+			}
+			out.matrix[x][y] = NewColorRGB(abs(a.R-b.R), abs(a.G-b.G), abs(a.B-b.B))
+		}
+	}
+	return out, nil
+}
+
+// DiffSum returns the sum of all color channels of the display
+// Called on the result of Diff, it is the total per-channel absolute difference
+// and is 0 only when the two compared displays were identical
+func (d *Display) DiffSum() (sum int) {
+	d.PixelScan(func(x, y int, c Color) {
+		rgb, _ := colorToRGB(c)
+		sum += rgb.R + rgb.G + rgb.B
+	})
+	return sum
+}
+
+// Equal returns true if both displays have the same dimensions and the same color at every pixel
+// Named and inline colors with the same RGB value are considered equal
+func (d *Display) Equal(other *Display) bool {
+	if other == nil || d.maxX != other.maxX || d.maxY != other.maxY {
+		return false
+	}
+	for x := 0; x < d.maxX; x++ {
+		for y := 0; y < d.maxY; y++ {
+			if !sameColor(d.matrix[x][y], other.matrix[x][y]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ComparePixel checks that the pixel at (x,y) has the expected color
+// Named and inline colors with the same RGB value are considered equal
+// Returns an error naming the pixel and both colors if they differ, or errOutOfBounds
+// if (x,y) is outside the display
+func (d *Display) ComparePixel(x, y int, expected Color) error {
+	got, err := d.getPixel(x, y)
+	if err != nil {
+		return err
+	}
+	if !sameColor(got, expected) {
+		return fmt.Errorf("pixel (%d,%d): got %s, want %s", x, y, got.name, expected.name)
+	}
+	return nil
+}
+
+// AssertRegion checks every pixel of the rectangle with corners (x0,y0) and (x1,y1), both included,
+// against the expected color
+// Returns one ComparePixel error for every pixel that does not match
+func (d *Display) AssertRegion(x0, y0, x1, y1 int, expected Color) (errs []error) {
+	for x := min(x0, x1); x <= max(x0, x1); x++ {
+		for y := min(y0, y1); y <= max(y0, y1); y++ {
+			if err := d.ComparePixel(x, y, expected); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errs
+}
+
+// AssertColumnUniform returns true if every pixel in column x has the expected color
+// Returns false if x is outside the display
+func (d *Display) AssertColumnUniform(x int, expected Color) bool {
+	if x < 0 || x >= d.maxX {
+		return false
+	}
+	return len(d.AssertRegion(x, 0, x, d.maxY-1, expected)) == 0
+}
+
+// Count returns the number of pixels of the display with color c
+// Named and inline colors with the same RGB value are counted alike; an unknown color counts 0
+func (d *Display) Count(c Color) int {
+	return d.CountInRegion(c, 0, 0, d.maxX-1, d.maxY-1)
+}
+
+// CountInRegion returns the number of pixels with color c in the rectangle with corners
+// (x0,y0) and (x1,y1), both included; the parts of the rectangle outside the display are ignored
+// Returns 0 for an unknown color
+func (d *Display) CountInRegion(c Color, x0, y0, x1, y1 int) (n int) {
+	want, err := colorToRGB(c)
+	if err != nil {
+		return 0
+	}
+	for x := max(min(x0, x1), 0); x <= min(max(x0, x1), d.maxX-1); x++ {
+		for y := max(min(y0, y1), 0); y <= min(max(y0, y1), d.maxY-1); y++ {
+			if got, err := colorToRGB(d.matrix[x][y]); err == nil && got == want {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// ColorCoverage returns the fraction of the display's pixels with color c
+// Returns 0 for an unknown color or a display with no pixels
+func (d *Display) ColorCoverage(c Color) float64 {
+	if d.maxX == 0 || d.maxY == 0 {
+		return 0
+	}
+	return float64(d.Count(c)) / float64(d.maxX*d.maxY)
+}
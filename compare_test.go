@@ -0,0 +1,63 @@
+package main
+
+import "testing"
+
+// TestDiff_Identical checks that identical displays diff to all black with a zero sum
+func TestDiff_Identical(t *testing.T) {
+	a, b := newDisplay(8, 6), newDisplay(8, 6)
+	diff, err := a.Diff(b)
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	if !diff.AssertColumnUniform(0, NewColor("black")) || diff.DiffSum() != 0 {
+		t.Errorf("identical displays diff to a sum of %d, want 0", diff.DiffSum())
+	}
+}
+
+// TestDiff_OnePixel checks the per-channel difference of a single changed pixel
+func TestDiff_OnePixel(t *testing.T) {
+	a, b := newDisplay(8, 6), newDisplay(8, 6)
+	b.matrix[3][2] = NewColor("red")
+	diff, err := a.Diff(b)
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	if err := diff.ComparePixel(3, 2, NewColorRGB(0, 255, 255)); err != nil {
+		t.Error(err)
+	}
+	if got := diff.DiffSum(); got != 510 {
+		t.Errorf("DiffSum is %d, want 510", got)
+	}
+}
+
+// TestDiff_DimensionMismatch checks that displays of different sizes cannot be diffed
+func TestDiff_DimensionMismatch(t *testing.T) {
+	if _, err := newDisplay(8, 6).Diff(newDisplay(6, 8)); err != errDimensionMismatch {
+		t.Errorf("got %v, want errDimensionMismatch", err)
+	}
+}
+
+// TestCount_Rectangle checks Count, CountInRegion and ColorCoverage for a 10x10 rectangle on a
+// 100x100 display, and that an unknown color counts as 0
+func TestCount_Rectangle(t *testing.T) {
+	red := NewColor("red")
+	d := newDisplay(100, 100)
+	if err := NewRectangle(Point{20, 30}, Point{30, 40}, red).DrawOn(d, DrawFill); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+	if got := d.Count(red); got != 100 {
+		t.Errorf("Count = %d, want 100", got)
+	}
+	if got := d.ColorCoverage(red); got != 0.01 {
+		t.Errorf("ColorCoverage = %v, want 0.01", got)
+	}
+	if got := d.CountInRegion(red, 25, 0, 99, 99); got != 50 {
+		t.Errorf("CountInRegion over the right half of the rectangle = %d, want 50", got)
+	}
+	if got := d.Count(NewColor("mauve")); got != 0 {
+		t.Errorf("Count of an unknown color = %d, want 0", got)
+	}
+	if got := d.ColorCoverage(NewColor("mauve")); got != 0 {
+		t.Errorf("ColorCoverage of an unknown color = %v, want 0", got)
+	}
+}
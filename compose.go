@@ -0,0 +1,149 @@
+package main
+
+// mod returns a modulo n, always in the range [0, n)
+func mod(a, n int) int {
+	return ((a % n) + n) % n
+}
+
+// Tile fills the display with copies of src laid out in a grid
+// The grid is aligned so that a tile starts at (offsetX, offsetY) and wraps around the
+// display's edges; tiles crossing the edge are clipped
+// Returns errEmptyDisplay if src has no pixels
+func (d *Display) Tile(src *Display, offsetX, offsetY int) error {
+	if src == nil || src.maxX == 0 || src.maxY == 0 {
+		return errEmptyDisplay
+	}
+	for x := 0; x < d.maxX; x++ {
+		for y := 0; y < d.maxY; y++ {
+			d.matrix[x][y] = src.matrix[mod(x-offsetX, src.maxX)][mod(y-offsetY, src.maxY)]
+		}
+	}
+	return nil
+}
+
+// TileOf returns a new display of the receiver's size tiled with pattern from (0,0)
+// The receiver is not modified; an empty pattern leaves the new display white
+func (d *Display) TileOf(pattern *Display) *Display {
+	out := newDisplay(d.maxX, d.maxY)
+	out.Tile(pattern, 0, 0)
+	return out
+}
+
+// DrawPattern fills the shape with the pattern tiled from (0,0) instead of the shape's own color
+// Pixel (x,y) of the shape gets the color of pattern pixel (x mod width, y mod height)
+// Returns errEmptyDisplay if pattern has no pixels, or any error reported by the shape's DrawOn method
+func (d *Display) DrawPattern(g geometry, pattern *Display) error {
+	if pattern == nil || pattern.maxX == 0 || pattern.maxY == 0 {
+		return errEmptyDisplay
+	}
+	return g.DrawOn(shadeScreen{d, func(x, y int) Color {
+		return pattern.matrix[mod(x, pattern.maxX)][mod(y, pattern.maxY)]
+	}}, DrawFill)
+}
+
+// BlitRegion copies the w x h block of src with its corner at (srcX,srcY) to (dstX,dstY) on the receiver
+// src may be the receiver itself; overlapping blocks are copied through a temporary buffer
+// so the result is as if the whole block had been read before any pixel was written
+// Returns errNilDisplay if src is nil, errInvalidShape for a negative w or h, or errOutOfBounds
+// if either block is not entirely inside its display
+func (d *Display) BlitRegion(src *Display, srcX, srcY, w, h, dstX, dstY int) error {
+	if src == nil {
+		return errNilDisplay
+	}
+	if w < 0 || h < 0 {
+		return errInvalidShape
+	}
+	inside := func(disp *Display, x, y int) bool {
+		return x >= 0 && y >= 0 && x+w <= disp.maxX && y+h <= disp.maxY
+	}
+	if !inside(src, srcX, srcY) || !inside(d, dstX, dstY) {
+		return errOutOfBounds
+	}
+
+	columns := src.matrix[srcX : srcX+w]
+	if src == d {
+		columns = make([][]Color, w)
+		for i := range columns {
+			columns[i] = append([]Color(nil), src.matrix[srcX+i][srcY:srcY+h]...)
+		}
+		srcY = 0
+	}
+	for i, col := range columns {
+		copy(d.matrix[dstX+i][dstY:dstY+h], col[srcY:srcY+h])
+	}
+	return nil
+}
+
+// copyInto copies every pixel of src into d with src's (0,0) placed at (dx, dy)
+// Pixels that land outside d are skipped
+func (d *Display) copyInto(src *Display, dx, dy int) {
+	for x := 0; x < src.maxX; x++ {
+		for y := 0; y < src.maxY; y++ {
+			if px, py := x+dx, y+dy; px >= 0 && py >= 0 && px < d.maxX && py < d.maxY {
+				d.matrix[px][py] = src.matrix[x][y]
+			}
+		}
+	}
+}
+
+// AppendBelow returns a new display with the receiver on top and other underneath
+// The new display is as wide as the wider of the two; the narrower one is padded with white
+// Returns errNilDisplay if other is nil
+func (d *Display) AppendBelow(other *Display) (*Display, error) {
+	return CombineVertical([]*Display{d, other})
+}
+
+// AppendRight returns a new display with the receiver on the left and other on the right
+// The new display is as tall as the taller of the two; the shorter one is padded with white
+// Returns errNilDisplay if other is nil
+func (d *Display) AppendRight(other *Display) (*Display, error) {
+	return CombineHorizontal([]*Display{d, other})
+}
+
+// CombineVertical stacks the displays top to bottom into a new display
+// The new display is as wide as the widest input; narrower inputs are padded with white
+// Returns errNilDisplay if any display is nil, or errEmptyDisplay if there are none
+func CombineVertical(displays []*Display) (*Display, error) {
+	return combine(displays, true)
+}
+
+// CombineHorizontal places the displays left to right into a new display
+// The new display is as tall as the tallest input; shorter inputs are padded with white
+// Returns errNilDisplay if any display is nil, or errEmptyDisplay if there are none
+func CombineHorizontal(displays []*Display) (*Display, error) {
+	return combine(displays, false)
+}
+
+// combine lays the displays out one after another, top to bottom if vertical
+// and left to right otherwise
+func combine(displays []*Display, vertical bool) (*Display, error) {
+	if len(displays) == 0 {
+		return nil, errEmptyDisplay
+	}
+
+	// Work out the size of the result
+	width, height := 0, 0
+	for _, src := range displays {
+		if src == nil {
+			return nil, errNilDisplay
+		}
+		if vertical {
+			width, height = max(width, src.maxX), height+src.maxY
+		} else {
+			width, height = width+src.maxX, max(height, src.maxY)
+		}
+	}
+
+	out := newDisplay(width, height)
+	offset := 0
+	for _, src := range displays {
+		if vertical {
+			out.copyInto(src, 0, offset)
+			offset += src.maxY
+		} else {
+			out.copyInto(src, offset, 0)
+			offset += src.maxX
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,191 @@
+package main
+
+import "testing"
+
+// checkerboard returns a size x size display of black and white squares with the given side
+func checkerboard(size, side int) *Display {
+	d := newDisplay(size, size)
+	for x := 0; x < size; x++ {
+		for y := 0; y < size; y++ {
+			if (x/side+y/side)%2 == 0 {
+				d.matrix[x][y] = NewColor("black")
+			}
+		}
+	}
+	return d
+}
+
+// TestTile_Checkerboard checks that tiling a 10x10 checkerboard into a 100x100 display repeats
+// it in all 100 tiles, with the grid shifted by the offset
+func TestTile_Checkerboard(t *testing.T) {
+	src := checkerboard(10, 5)
+	for _, off := range []Point{{0, 0}, {3, 7}, {-4, 12}} {
+		d := newDisplay(100, 100)
+		if err := d.Tile(src, off.x, off.y); err != nil {
+			t.Fatalf("Tile: %v", err)
+		}
+		for x := 0; x < 100; x++ {
+			for y := 0; y < 100; y++ {
+				want := src.matrix[mod(x-off.x, 10)][mod(y-off.y, 10)]
+				if d.matrix[x][y] != want {
+					t.Fatalf("offset %v: pixel (%d,%d) is %v, want %v", off, x, y, d.matrix[x][y], want)
+				}
+			}
+		}
+	}
+
+	tiled := newDisplay(100, 100)
+	if err := tiled.Tile(src, 0, 0); err != nil {
+		t.Fatalf("Tile: %v", err)
+	}
+	if !newDisplay(100, 100).TileOf(src).Equal(tiled) {
+		t.Error("TileOf differs from Tile at offset (0,0)")
+	}
+}
+
+// TestTile_EmptySource checks that an empty source display is rejected
+func TestTile_EmptySource(t *testing.T) {
+	if err := newDisplay(10, 10).Tile(newDisplay(0, 0), 0, 0); err != errEmptyDisplay {
+		t.Errorf("got %v, want errEmptyDisplay", err)
+	}
+}
+
+// TestAppendBelow_Identical checks that stacking two identical 50x50 displays gives a 50x100
+// display with a copy of the source in each half
+func TestAppendBelow_Identical(t *testing.T) {
+	src := checkerboard(50, 7)
+	out, err := src.AppendBelow(src)
+	if err != nil {
+		t.Fatalf("AppendBelow: %v", err)
+	}
+	if out.maxX != 50 || out.maxY != 100 {
+		t.Fatalf("got a %dx%d display, want 50x100", out.maxX, out.maxY)
+	}
+	for x := 0; x < 50; x++ {
+		for y := 0; y < 100; y++ {
+			if want := src.matrix[x][y%50]; out.matrix[x][y] != want {
+				t.Fatalf("pixel (%d,%d) is %v, want %v", x, y, out.matrix[x][y], want)
+			}
+		}
+	}
+}
+
+// TestAppendRight_Padding checks that the shorter display is padded with white
+func TestAppendRight_Padding(t *testing.T) {
+	tall, short := newDisplay(3, 6), newDisplay(4, 2)
+	short.matrix[1][1] = NewColor("red")
+	for _, src := range []*Display{tall, short} {
+		for x := 0; x < src.maxX; x++ {
+			src.matrix[x][0] = NewColor("black")
+		}
+	}
+	out, err := tall.AppendRight(short)
+	if err != nil {
+		t.Fatalf("AppendRight: %v", err)
+	}
+	if out.maxX != 7 || out.maxY != 6 {
+		t.Fatalf("got a %dx%d display, want 7x6", out.maxX, out.maxY)
+	}
+	for _, err := range out.AssertRegion(0, 0, 6, 0, NewColor("black")) {
+		t.Error(err)
+	}
+	if err := out.ComparePixel(4, 1, NewColor("red")); err != nil {
+		t.Error(err)
+	}
+	for _, err := range out.AssertRegion(3, 2, 6, 5, NewColor("white")) {
+		t.Error(err)
+	}
+}
+
+// TestCombineVertical_Nil checks that a nil display is rejected
+func TestCombineVertical_Nil(t *testing.T) {
+	if _, err := CombineVertical([]*Display{newDisplay(2, 2), nil}); err != errNilDisplay {
+		t.Errorf("got %v, want errNilDisplay", err)
+	}
+	if _, err := newDisplay(2, 2).AppendBelow(nil); err != errNilDisplay {
+		t.Errorf("AppendBelow(nil): got %v, want errNilDisplay", err)
+	}
+}
+
+// TestTile_CopiesPixels checks that Tile copies the source pixels as they are,
+// including colors that drawPixel would reject
+func TestTile_CopiesPixels(t *testing.T) {
+	src := newDisplay(2, 2)
+	src.matrix[1][1] = Color{}
+	d := newDisplay(4, 4)
+	if err := d.Tile(src, 0, 0); err != nil {
+		t.Fatalf("Tile: %v", err)
+	}
+	for _, p := range []Point{{1, 1}, {3, 1}, {1, 3}, {3, 3}} {
+		if d.matrix[p.x][p.y] != (Color{}) {
+			t.Errorf("pixel %v is %v, want the empty source color", p, d.matrix[p.x][p.y])
+		}
+	}
+}
+
+// TestDrawPattern_CheckerboardCircle checks that a circle drawn with a checkerboard pattern shows the
+// tiled checkerboard exactly where the plain circle would be and nothing outside it
+func TestDrawPattern_CheckerboardCircle(t *testing.T) {
+	red := NewColor("red")
+	pattern := checkerboard(10, 5)
+	c := NewCircle(Point{20, 20}, 12, red)
+	plain, d := newDisplay(41, 41), newDisplay(41, 41)
+	if err := c.DrawOn(plain, DrawFill); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+	if err := d.DrawPattern(c, pattern); err != nil {
+		t.Fatalf("DrawPattern: %v", err)
+	}
+
+	black := 0
+	plain.PixelScan(func(x, y int, col Color) {
+		want := NewColor("white")
+		if col == red {
+			want = pattern.matrix[x%10][y%10]
+		}
+		if err := d.ComparePixel(x, y, want); err != nil {
+			t.Error(err)
+		}
+		if col == red && want == NewColor("black") {
+			black++
+		}
+	})
+	if black == 0 || d.Count(NewColor("black")) != black {
+		t.Errorf("got %d black pixels, want the %d of the checkerboard inside the circle", d.Count(NewColor("black")), black)
+	}
+	if err := d.DrawPattern(c, newDisplay(0, 0)); err != errEmptyDisplay {
+		t.Errorf("empty pattern: got %v, want errEmptyDisplay", err)
+	}
+}
+
+// coordinateColors returns a w x h display where every pixel has its own color, encoding (x,y)
+func coordinateColors(w, h int) *Display {
+	d := newDisplay(w, h)
+	d.PixelWalk(func(x, y int, c Color) Color { return NewColorRGB(x, y, 0) })
+	return d
+}
+
+// TestBlitRegion_OverlappingSelf checks that blitting a block onto an overlapping part of the same
+// display copies the original pixels in both directions, as if read before any was written
+func TestBlitRegion_OverlappingSelf(t *testing.T) {
+	for _, c := range []struct{ srcX, srcY, dstX, dstY int }{{2, 2, 5, 4}, {5, 4, 2, 2}, {3, 3, 3, 6}, {3, 6, 3, 3}} {
+		d, orig := coordinateColors(20, 20), coordinateColors(20, 20)
+		if err := d.BlitRegion(d, c.srcX, c.srcY, 10, 8, c.dstX, c.dstY); err != nil {
+			t.Fatalf("BlitRegion: %v", err)
+		}
+		d.PixelScan(func(x, y int, got Color) {
+			want := orig.matrix[x][y]
+			if dx, dy := x-c.dstX, y-c.dstY; dx >= 0 && dx < 10 && dy >= 0 && dy < 8 {
+				want = orig.matrix[c.srcX+dx][c.srcY+dy]
+			}
+			if got != want {
+				t.Errorf("(%d,%d) to (%d,%d): pixel (%d,%d) is %v, want %v", c.srcX, c.srcY, c.dstX, c.dstY, x, y, got, want)
+			}
+		})
+	}
+
+	d := coordinateColors(20, 20)
+	if err := d.BlitRegion(d, 0, 0, 10, 8, 12, 0); err != errOutOfBounds {
+		t.Errorf("block past the edge: got %v, want errOutOfBounds", err)
+	}
+}
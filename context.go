@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"errors"
+)
+
+// ctxScreen wraps a screen so that drawing stops once the context is done
+// The context is checked before every pixel, so long scanline loops stop promptly
+type ctxScreen struct {
+	screen
+	ctx context.Context // Context that cancels the drawing
+}
+
+// drawPixel returns ctx.Err() if the context is done, and draws the pixel otherwise
+func (cs ctxScreen) drawPixel(x, y int, c Color) (err error) {
+	if err = cs.ctx.Err(); err != nil {
+		return err
+	}
+	return cs.screen.drawPixel(x, y, c)
+}
+
+// clips is the ctxScreen implementation of the clipper interface
+// Bounds are handled the same way as on the wrapped screen
+func (cs ctxScreen) clips() bool {
+	return !checksBounds(cs.screen)
+}
+
+// drawContext draws the shape on the screen in the default mode until ctx is done
+// Returns ctx.Err() without drawing anything if the context is already done
+func drawContext(ctx context.Context, g geometry, scn screen) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return g.DrawOn(ctxScreen{scn, ctx}, DrawDefault)
+}
+
+// DrawContext is the Rectangle implementation of the geometry.DrawContext method
+func (r Rectangle) DrawContext(ctx context.Context, scn screen) error {
+	return drawContext(ctx, r, scn)
+}
+
+// DrawContext is the Triangle implementation of the geometry.DrawContext method
+func (t Triangle) DrawContext(ctx context.Context, scn screen) error {
+	return drawContext(ctx, t, scn)
+}
+
+// DrawContext is the Circle implementation of the geometry.DrawContext method
+func (c Circle) DrawContext(ctx context.Context, scn screen) error {
+	return drawContext(ctx, c, scn)
+}
+
+// DrawContext is the Line implementation of the geometry.DrawContext method
+func (l Line) DrawContext(ctx context.Context, scn screen) error {
+	return drawContext(ctx, l, scn)
+}
+
+// DrawContext is the Polyline implementation of the geometry.DrawContext method
+func (pl Polyline) DrawContext(ctx context.Context, scn screen) error {
+	return drawContext(ctx, pl, scn)
+}
+
+// DrawContext is the Polygon implementation of the geometry.DrawContext method
+func (pg Polygon) DrawContext(ctx context.Context, scn screen) error {
+	return drawContext(ctx, pg, scn)
+}
+
+// DrawContext is the Parallelogram implementation of the geometry.DrawContext method
+func (p Parallelogram) DrawContext(ctx context.Context, scn screen) error {
+	return drawContext(ctx, p, scn)
+}
+
+// DrawAll draws the shapes on the display in order until ctx is done
+// A shape that fails for another reason does not stop the remaining shapes
+// Returns ctx.Err() as soon as the context is done, otherwise the errors of the
+// failed shapes joined together (nil if every shape was drawn)
+func (d *Display) DrawAll(ctx context.Context, shapes ...geometry) error {
+	var errs []error
+	for _, g := range shapes {
+		err := g.DrawContext(ctx, d)
+		if ctxErr := ctx.Err(); ctxErr != nil && errors.Is(err, ctxErr) {
+			return ctxErr
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestDrawContext_Deadline checks that a draw with an expired deadline stops before completing
+func TestDrawContext_Deadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	time.Sleep(time.Millisecond)
+
+	red := NewColor("red")
+	d := newDisplay(500, 500)
+	if err := NewRectangle(Point{0, 0}, Point{500, 500}, red).DrawContext(ctx, d); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want context.DeadlineExceeded", err)
+	}
+	if got := d.Count(red); got == 500*500 {
+		t.Error("the rectangle was drawn completely")
+	}
+}
+
+// TestDrawAll_Cancel checks that DrawAll stops at the first context error but carries on past other errors
+func TestDrawAll_Cancel(t *testing.T) {
+	red := NewColor("red")
+	d := newDisplay(20, 20)
+	bad := NewCircle(Point{5, 5}, 50, red)
+	good := NewRectangle(Point{0, 0}, Point{4, 4}, red)
+	if err := d.DrawAll(context.Background(), bad, good); !errors.Is(err, errOutOfBounds) {
+		t.Errorf("got %v, want errOutOfBounds", err)
+	}
+	if got := d.Count(red); got != 16 {
+		t.Errorf("got %d red pixels, want the 16 of the second shape", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	d = newDisplay(20, 20)
+	if err := d.DrawAll(ctx, good, good); err != context.Canceled {
+		t.Errorf("got %v, want context.Canceled", err)
+	}
+	if got := d.Count(red); got != 0 {
+		t.Errorf("got %d red pixels after cancelling, want 0", got)
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+// delaunayTriangle is a triangle of the Bowyer-Watson triangulation, stored as indices into
+// the working point list in counter-clockwise order
+type delaunayTriangle [3]int
+
+// inCircumcircle returns true if p lies strictly inside the circumcircle of the
+// counter-clockwise triangle abc
+// Points exactly on the circle are outside, so cocircular points keep their first triangulation
+func inCircumcircle(a, b, c, p [2]float64) bool {
+	ax, ay := a[0]-p[0], a[1]-p[1]
+	bx, by := b[0]-p[0], b[1]-p[1]
+	cx, cy := c[0]-p[0], c[1]-p[1]
+	det := (ax*ax+ay*ay)*(bx*cy-cx*by) - (bx*bx+by*by)*(ax*cy-cx*ay) + (cx*cx+cy*cy)*(ax*by-bx*ay)
+	return det > 0
+}
+
+// Delaunay returns the Delaunay triangulation of the points using the Bowyer-Watson algorithm:
+// no input point lies inside the circumcircle of any of the triangles
+// Repeated points are used once; every triangle gets the color c
+// Returns errInvalidShape if there are fewer than 3 distinct points or all of them are collinear
+func Delaunay(points []Point, c Color) ([]Triangle, error) {
+	// Drop repeated points and check that the rest span an area
+	var unique []Point
+	seen := map[Point]bool{}
+	for _, p := range points {
+		if !seen[p] {
+			seen[p] = true
+			unique = append(unique, p)
+		}
+	}
+	collinear := true
+	for i := 2; i < len(unique) && collinear; i++ {
+		collinear = cross(unique[0], unique[1], unique[i]) == 0
+	}
+	if collinear {
+		return nil, errInvalidShape
+	}
+
+	// Working points: the input followed by a super triangle that contains all of them
+	// It is made much larger than the points so that no hull triangle is lost with it
+	box := boxOf(unique)
+	size := float64(max(max(box.Max.x-box.Min.x, box.Max.y-box.Min.y), 1))
+	midX, midY := float64(box.Min.x+box.Max.x)/2, float64(box.Min.y+box.Max.y)/2
+	pts := make([][2]float64, 0, len(unique)+3)
+	for _, p := range unique {
+		pts = append(pts, [2]float64{float64(p.x), float64(p.y)})
+	}
+	n := len(pts)
+	pts = append(pts,
+		[2]float64{midX - 1000*size, midY - size},
+		[2]float64{midX + 1000*size, midY - size},
+		[2]float64{midX, midY + 1000*size},
+	)
+	triangles := []delaunayTriangle{{n, n + 1, n + 2}}
+
+	for i := 0; i < n; i++ {
+		// Remove every triangle whose circumcircle contains the new point, and count
+		// how often each edge of the removed triangles occurs
+		var kept []delaunayTriangle
+		edgeCount := map[[2]int]int{}
+		var edges [][2]int
+		for _, t := range triangles {
+			if !inCircumcircle(pts[t[0]], pts[t[1]], pts[t[2]], pts[i]) {
+				kept = append(kept, t)
+				continue
+			}
+			for k := 0; k < 3; k++ {
+				e := [2]int{t[k], t[(k+1)%3]}
+				key := [2]int{min(e[0], e[1]), max(e[0], e[1])}
+				if edgeCount[key] == 0 {
+					edges = append(edges, e)
+				}
+				edgeCount[key]++
+			}
+		}
+
+		// The edges used only once bound the hole; join each of them to the new point
+		// Edges keep the counter-clockwise direction of their removed triangle
+		for _, e := range edges {
+			if edgeCount[[2]int{min(e[0], e[1]), max(e[0], e[1])}] == 1 {
+				kept = append(kept, delaunayTriangle{e[0], e[1], i})
+			}
+		}
+		triangles = kept
+	}
+
+	var result []Triangle
+	for _, t := range triangles {
+		if t[0] >= n || t[1] >= n || t[2] >= n {
+			continue
+		}
+		result = append(result, Triangle{pt0: unique[t[0]], pt1: unique[t[1]], pt2: unique[t[2]], c: c, Thickness: 1})
+	}
+	return result, nil
+}
+
+// DrawDelaunay draws the edges of the Delaunay triangulation of the points
+// Edges shared by two triangles are drawn once
+// Returns errInvalidShape if there are fewer than 3 non-collinear points, errOutOfBounds if a
+// point is outside the display, or invalidColor for an unknown color
+func (d *Display) DrawDelaunay(points []Point, c Color) error {
+	triangles, err := Delaunay(points, c)
+	if err != nil {
+		return err
+	}
+	drawn := map[[2]Point]bool{}
+	for _, t := range triangles {
+		for _, e := range [][2]Point{{t.pt0, t.pt1}, {t.pt1, t.pt2}, {t.pt2, t.pt0}} {
+			if pointLess(e[1], e[0]) {
+				e[0], e[1] = e[1], e[0]
+			}
+			if drawn[e] {
+				continue
+			}
+			drawn[e] = true
+			if err = d.DrawLine(e[0].x, e[0].y, e[1].x, e[1].y, c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// DrawDelaunayFilled fills the triangles of the Delaunay triangulation of the points,
+// triangle i in colors[i % len(colors)]
+// Returns errEmptyPalette if there are no colors, or the same errors as DrawDelaunay
+func (d *Display) DrawDelaunayFilled(points []Point, colors []Color) error {
+	if len(colors) == 0 {
+		return errEmptyPalette
+	}
+	triangles, err := Delaunay(points, colors[0])
+	if err != nil {
+		return err
+	}
+	for i, t := range triangles {
+		t.c = colors[i%len(colors)]
+		if err = t.DrawOn(d, DrawFill); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import "testing"
+
+// TestDelaunay_Square checks that the four corners of a square give two triangles that share one
+// of the diagonals and together cover the square, rather than an hourglass of crossing edges
+func TestDelaunay_Square(t *testing.T) {
+	corners := []Point{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+	triangles, err := Delaunay(corners, NewColor("red"))
+	if err != nil {
+		t.Fatalf("Delaunay: %v", err)
+	}
+	if len(triangles) != 2 {
+		t.Fatalf("got %d triangles, want 2", len(triangles))
+	}
+
+	count := map[[2]Point]int{}
+	for _, tri := range triangles {
+		if a := shapeArea(tri); a != 50 {
+			t.Errorf("triangle %v has area %v, want 50", tri, a)
+		}
+		v := tri.Vertices()
+		for i := range v {
+			e := [2]Point{v[i], v[(i+1)%3]}
+			if pointLess(e[1], e[0]) {
+				e[0], e[1] = e[1], e[0]
+			}
+			count[e]++
+		}
+	}
+	if len(count) != 5 {
+		t.Errorf("got %d distinct edges, want the 4 sides and a diagonal", len(count))
+	}
+	for e, n := range count {
+		diagonal := e[0].x != e[1].x && e[0].y != e[1].y
+		if diagonal != (n == 2) {
+			t.Errorf("edge %v is in %d triangles", e, n)
+		}
+	}
+
+	d := newDisplay(11, 11)
+	if err := d.DrawDelaunay(corners, NewColor("red")); err != nil {
+		t.Fatalf("DrawDelaunay: %v", err)
+	}
+	if a, b := d.matrix[3][3] == NewColor("red"), d.matrix[3][7] == NewColor("red"); a == b {
+		t.Errorf("(3,3) drawn %v and (3,7) drawn %v, want exactly one diagonal", a, b)
+	}
+}
+
+// TestDelaunay_Collinear checks that collinear points are rejected
+func TestDelaunay_Collinear(t *testing.T) {
+	if _, err := Delaunay([]Point{{0, 0}, {1, 1}, {2, 2}, {3, 3}}, NewColor("red")); err != errInvalidShape {
+		t.Errorf("got %v, want errInvalidShape", err)
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import "testing"
+
+// TestRectangle_Draw checks the pixels covered by filled and outlined rectangles
+// The upper-right corner is exclusive, so a rectangle covers x in [ll.x,ur.x) and y in [ll.y,ur.y)
+func TestRectangle_Draw(t *testing.T) {
+	red, blue, white := NewColor("red"), NewColor("blue"), NewColor("white")
+	tests := []struct {
+		name   string
+		rect   Rectangle
+		mode   DrawMode
+		inside Point // A pixel expected in the fill color
+		border Point // A pixel on the edge, expected in the edge color
+		edge   Color // Color expected on the edge
+	}{
+		{"fill", NewRectangle(Point{2, 3}, Point{8, 7}, red), DrawFill, Point{5, 5}, Point{2, 3}, red},
+		{"outline", NewRectangle(Point{2, 3}, Point{8, 7}, red), DrawOutline, Point{5, 5}, Point{7, 6}, red},
+		{"stroke", NewRectangle(Point{2, 3}, Point{8, 7}, red).WithStroke(blue), DrawBoth, Point{5, 5}, Point{2, 6}, blue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newDisplay(10, 10)
+			if err := tt.rect.DrawOn(d, tt.mode); err != nil {
+				t.Fatalf("DrawOn: %v", err)
+			}
+
+			if err := d.ComparePixel(tt.border.x, tt.border.y, tt.edge); err != nil {
+				t.Error(err)
+			}
+			inside := red
+			if tt.mode == DrawOutline {
+				inside = white
+			}
+			if err := d.ComparePixel(tt.inside.x, tt.inside.y, inside); err != nil {
+				t.Error(err)
+			}
+
+			// Nothing is drawn at or beyond the exclusive upper-right corner
+			for _, err := range d.AssertRegion(8, 0, 9, 9, white) {
+				t.Error(err)
+			}
+			for _, err := range d.AssertRegion(0, 7, 9, 9, white) {
+				t.Error(err)
+			}
+			for x := 0; x < 2; x++ {
+				if !d.AssertColumnUniform(x, white) {
+					t.Errorf("column %d is not all white", x)
+				}
+			}
+		})
+	}
+}
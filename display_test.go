@@ -0,0 +1,37 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// AssertMatchesGolden fails the test if the display does not match the golden PPM image goldenFile
+// If the file does not exist and UPDATE_GOLDEN=1 is set, the display is written as the new golden image
+func AssertMatchesGolden(t *testing.T, d *Display, goldenFile string) {
+	t.Helper()
+	if _, err := os.Stat(goldenFile); errors.Is(err, os.ErrNotExist) && os.Getenv("UPDATE_GOLDEN") == "1" {
+		if err = os.MkdirAll(filepath.Dir(goldenFile), 0o755); err != nil {
+			t.Fatalf("creating %s: %v", filepath.Dir(goldenFile), err)
+		}
+		if err = d.screenShot(strings.TrimSuffix(goldenFile, ".ppm")); err != nil {
+			t.Fatalf("writing %s: %v", goldenFile, err)
+		}
+		return
+	}
+	if err := CompareGolden(d, goldenFile); err != nil {
+		t.Errorf("%s does not match: %v", goldenFile, err)
+	}
+}
+
+// TestRectangle_Golden checks a stroked rectangle against testdata/golden/rectangle.ppm
+func TestRectangle_Golden(t *testing.T) {
+	d := newDisplay(16, 12)
+	r := NewRectangle(Point{3, 2}, Point{13, 9}, NewColor("red")).WithStroke(NewColor("blue"))
+	if err := r.DrawOn(d, DrawBoth); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+	AssertMatchesGolden(t, d, filepath.Join(GoldenDir(), "rectangle.ppm"))
+}
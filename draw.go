@@ -1,411 +1,1572 @@
-// Package main implements a geometric shape drawing application
-// using Go interfaces. This application allows users to draw
-// various shapes (rectangles, triangles, circles) of different colors
-// on a virtual screen and save the result as a PPM image file.
-//
-// CS 341, Spring 2025
-// Project 5 – Geometry Using Go Interfaces
-// Joel Lau Arrieta
-package main
-
-import (
-	"errors"
-	"fmt"
-	"math"
-	"os"
-)
-
-// RGB represents a color in RGB format with red, green, and blue components
-// Each value ranges from 0 to 255
-// Used for mapping color names to actual RGB values
-// Example: RGB{255, 0, 0} is red
-type RGB struct {
-	R, G, B int // Values range from 0-255
-}
-
-// Color represents a color by its name
-// The name must be one of the predefined colors in the ColorMap
-// Example: Color{"red"}
-type Color struct {
-	Name string
-}
-
-// Point represents a 2D point in the coordinate system
-// x and y are integer coordinates
-type Point struct {
-	x, y int // x and y coordinates
-}
-
-// ColorMap maps color names to RGB values
-// The application supports the following colors:
-// red, green, blue, yellow, orange, purple, brown, black, white
-var ColorMap = map[string]RGB{
-	"red":    {255, 0, 0},
-	"green":  {0, 255, 0},
-	"blue":   {0, 0, 255},
-	"yellow": {255, 255, 0},
-	"orange": {255, 164, 0},
-	"purple": {128, 0, 128},
-	"brown":  {165, 42, 42},
-	"black":  {0, 0, 0},
-	"white":  {255, 255, 255},
-}
-
-// Error types defined for different error cases in the application
-// errOutOfBounds: Used when a shape or pixel is outside the display
-// invalidColor: Used when a color is not in the ColorMap
-// fileError: Used when there is a problem creating or writing to a file
-var errOutOfBounds = errors.New("Attempt to draw a figure out of bounds of the screen.")
-var invalidColor = errors.New("Attempt to use an invalid color.")
-var fileError = errors.New("Unable to create PPM file.")
-
-// geometry interface defines methods that all shapes must implement
-// draw: Draws the shape on the provided screen
-// printShape: Returns a string representation of the shape
-type geometry interface {
-	// draw draws the shape on the provided screen
-	draw(scn screen) (err error)
-
-	// printShape returns a string representation of the shape
-	printShape() (s string)
-}
-
-// Rectangle struct represents a rectangle defined by lower-left and upper-right points
-// ll: Lower-left corner, ur: Upper-right corner, c: Fill color
-type Rectangle struct {
-	ll Point // Lower-left corner
-	ur Point // Upper-right corner
-	c  Color // Fill color
-}
-
-// Triangle struct represents a triangle defined by three points
-// pt0, pt1, pt2: The three vertices, c: Fill color
-type Triangle struct {
-	pt0 Point // First point
-	pt1 Point // Second point
-	pt2 Point // Third point
-	c   Color // Fill color
-}
-
-// Circle struct represents a circle defined by center point and radius
-// center: Center point, r: Radius, c: Fill color
-type Circle struct {
-	center Point // Center point
-	r      int   // Radius
-	c      Color // Fill color
-}
-
-// screen interface defines methods that any display screen must implement
-// Used to abstract the display implementation
-// initialize: Create a screen with given dimensions
-// getMaxXY: Get the maximum x and y dimensions
-// drawPixel: Color a pixel at a location
-// getPixel: Get the color of a pixel
-// clearScreen: Reset all pixels to white
-// screenShot: Save the screen to a PPM file
-type screen interface {
-	initialize(x, y int)
-	getMaxXY() (x, y int)
-	drawPixel(x, y int, c Color) (err error)
-	getPixel(x, y int) (c Color, err error)
-	clearScreen()
-	screenShot(f string) (err error)
-}
-
-// Display struct implements the screen interface
-// maxX, maxY: Dimensions of the display
-// matrix: 2D slice representing pixel colors
-type Display struct {
-	maxX   int       // Width of the display
-	maxY   int       // Height of the display
-	matrix [][]Color // 2D slice representing pixel colors
-}
-
-// colorUnknown checks if a color is not defined in the ColorMap
-// Returns true if the color is unknown (not in the map)
-func colorUnknown(c Color) bool {
-	_, exists := ColorMap[c.Name]
-	return !exists
-}
-
-// outOfBounds checks if a given point would go out of bounds of the screen.
-// Returns true if the point is out of bounds, false otherwise.
-func outOfBounds(p Point, scn screen) bool {
-	xMax, yMax := scn.getMaxXY()
-	return p.x < 0 || p.x >= xMax || p.y < 0 || p.y >= yMax
-}
-
-// interpolate() is a helper function
-// Linearly interpolates between two points (l0, d0) and (l1, d1)
-// Returns a slice of integer values representing the interpolated points
-func interpolate(l0, d0, l1, d1 int) (values []int) {
-	a := float64(d1-d0) / float64(l1-l0)
-	d := float64(d0)
-
-	count := l1 - l0 + 1
-	for ; count > 0; count-- {
-		values = append(values, int(d))
-		d = d + a
-	}
-	return
-}
-
-// draw is the Triangle implementation of the geometry.draw method
-// Draws a filled triangle using scanline interpolation
-// Returns an error if the triangle is out of bounds or if the color is invalid
-func (tri Triangle) draw(scn screen) (err error) {
-	// Check if drawing this triangle would cause either error
-	if outOfBounds(tri.pt0, scn) || outOfBounds(tri.pt1, scn) || outOfBounds(tri.pt2, scn) {
-		return errOutOfBounds
-	}
-	if colorUnknown(tri.c) {
-		return invalidColor
-	}
-
-	// Sort the points so that y0 <= y1 <= y2
-	y0 := tri.pt0.y
-	y1 := tri.pt1.y
-	y2 := tri.pt2.y
-	if y1 < y0 {
-		tri.pt1, tri.pt0 = tri.pt0, tri.pt1
-	}
-	if y2 < y0 {
-		tri.pt2, tri.pt0 = tri.pt0, tri.pt2
-	}
-	if y2 < y1 {
-		tri.pt2, tri.pt1 = tri.pt1, tri.pt2
-	}
-	x0, y0, x1, y1, x2, y2 := tri.pt0.x, tri.pt0.y, tri.pt1.x, tri.pt1.y, tri.pt2.x, tri.pt2.y
-
-	// Interpolate the x-coordinates for the triangle edges
-	x01 := interpolate(y0, x0, y1, x1)
-	x12 := interpolate(y1, x1, y2, x2)
-	x02 := interpolate(y0, x0, y2, x2)
-
-	// Concatenate the short sides
-	x012 := append(x01[:len(x01)-1], x12...)
-
-	// Determine which is left and which is right
-	var x_left, x_right []int
-	m := len(x012) / 2
-	if x02[m] < x012[m] {
-		x_left = x02
-		x_right = x012
-	} else {
-		x_left = x012
-		x_right = x02
-	}
-
-	// Draw the horizontal segments (scanlines)
-	for y := y0; y <= y2; y++ {
-		for x := x_left[y-y0]; x <= x_right[y-y0]; x++ {
-			scn.drawPixel(x, y, tri.c)
-		}
-	}
-	return
-}
-
-// insideCircle() is a helper function
-// Returns true if the tile point is inside the circle with given center and radius
-func insideCircle(center, tile Point, r float64) (inside bool) {
-	var dx float64 = float64(center.x - tile.x)
-	var dy float64 = float64(center.y - tile.y)
-	var distance float64 = math.Sqrt(dx*dx + dy*dy)
-	return distance <= r
-}
-
-// draw is the Rectangle implementation of the geometry.draw method
-// It fills in every pixel inside the rectangle with the specified color
-// Returns an error if the rectangle is out of bounds or if the color is invalid
-func (r Rectangle) draw(scn screen) (err error) {
-	// Check if rectangle is out of bounds
-	if outOfBounds(r.ll, scn) || outOfBounds(r.ur, scn) {
-		return errOutOfBounds
-	}
-	if colorUnknown(r.c) {
-		return invalidColor
-	}
-
-	// Fill in rectangle by drawing each pixel (exclusive upper bounds)
-	for x := r.ll.x; x < r.ur.x; x++ {
-		for y := r.ll.y; y < r.ur.y; y++ {
-			err = scn.drawPixel(x, y, r.c)
-			if err != nil {
-				return err
-			}
-		}
-	}
-	return nil
-}
-
-// draw is the Circle implementation of the geometry.draw method
-// Draws a filled circle using the insideCircle helper
-// Only draws pixels within the display bounds
-// Returns an error if the circle is out of bounds or if the color is invalid
-func (c Circle) draw(scn screen) (err error) {
-	maxX, maxY := scn.getMaxXY()
-	if c.center.x-c.r < 0 || c.center.y-c.r < 0 ||
-		c.center.x+c.r >= maxX || c.center.y+c.r >= maxY {
-		return errOutOfBounds
-	}
-	if colorUnknown(c.c) {
-		return invalidColor
-	}
-
-	// Iterate over the bounding box of the circle
-	for y := c.center.y - c.r; y <= c.center.y+c.r; y++ {
-		for x := c.center.x - c.r; x <= c.center.x+c.r; x++ {
-			if insideCircle(c.center, Point{x, y}, float64(c.r)) {
-				if x >= 0 && x < maxX && y >= 0 && y < maxY {
-					scn.drawPixel(x, y, c.c)
-				}
-			}
-		}
-	}
-	return
-}
-
-// printShape is the Rectangle implementation of the geometry.printShape method
-// Returns a string description of the rectangle with its coordinates
-func (r Rectangle) printShape() (s string) {
-	return fmt.Sprintf("Rectangle: (%d,%d) to (%d,%d)", r.ll.x, r.ll.y, r.ur.x, r.ur.y)
-}
-
-// printShape is the Triangle implementation of the geometry.printShape method
-// Returns a string description of the triangle with its coordinates
-func (t Triangle) printShape() (s string) {
-	return fmt.Sprintf("Triangle: (%d,%d), (%d,%d), (%d,%d)",
-		t.pt0.x, t.pt0.y, t.pt1.x, t.pt1.y, t.pt2.x, t.pt2.y)
-}
-
-// printShape is the Circle implementation of the geometry.printShape method
-// Returns a string description of the circle with its center and radius
-func (c Circle) printShape() (s string) {
-	return fmt.Sprintf("Circle: centered around (%d,%d) with radius %d",
-		c.center.x, c.center.y, c.r)
-}
-
-// initialize creates and initializes a display with the specified dimensions
-// Sets all pixels to white (the default color)
-func (d *Display) initialize(x, y int) {
-	d.maxX = x
-	d.maxY = y
-	d.matrix = make([][]Color, x)
-	for i := range d.matrix {
-		d.matrix[i] = make([]Color, y)
-		for j := range d.matrix[i] {
-			d.matrix[i][j] = Color{"white"} // Initialize to white
-		}
-	}
-}
-
-// getMaxXY returns the width and height dimensions of the display
-func (d *Display) getMaxXY() (x, y int) {
-	return d.maxX, d.maxY
-}
-
-// drawPixel sets the color of a pixel at coordinates (x,y)
-// Returns errOutOfBounds error if the coordinates are outside the display
-// Returns invalidColor error if the specified color is not recognized
-func (d *Display) drawPixel(x, y int, c Color) (err error) {
-	// Check if pixel is out of bounds
-	if x < 0 || y < 0 || x >= d.maxX || y >= d.maxY {
-		return errOutOfBounds
-	}
-
-	// Check if color is valid
-	if colorUnknown(c) {
-		return invalidColor
-	}
-
-	// Draw the pixel - store directly
-	d.matrix[x][y] = c
-	return nil
-}
-
-// getPixel retrieves the color of a pixel at coordinates (x,y)
-// Returns errOutOfBounds error if the coordinates are outside the display
-// Returns invalidColor error if the stored color is not recognized
-func (d *Display) getPixel(x, y int) (c Color, err error) {
-	// Check if pixel is out of bounds
-	if x < 0 || y < 0 || x >= d.maxX || y >= d.maxY {
-		return Color{}, errOutOfBounds
-	}
-
-	// Get the pixel color - retrieve directly
-	c = d.matrix[x][y]
-
-	// Check if color is valid
-	if colorUnknown(c) {
-		return c, invalidColor
-	}
-
-	return c, nil
-}
-
-// clearScreen resets all pixels in the display to white color
-func (d *Display) clearScreen() {
-	for i := range d.matrix {
-		for j := range d.matrix[i] {
-			d.matrix[i][j] = Color{"white"}
-		}
-	}
-}
-
-// screenShot saves the current state of the display to a PPM image file
-// The file format follows the P3 PPM format with RGB values
-// Returns fileError if there was a problem creating or writing to the file
-func (d *Display) screenShot(f string) (err error) {
-	file, err := os.Create(f + ".ppm")
-	if err != nil {
-		return fileError
-	}
-	defer file.Close()
-
-	// Write header: columns (width) first, then rows (height)
-	if _, err = fmt.Fprintf(file, "P3\n%d %d\n255\n", d.maxX, d.maxY); err != nil {
-		return fileError
-	}
-
-	// Write pixel data row by row, top to bottom
-	for y := 0; y < d.maxY; y++ {
-		for x := 0; x < d.maxX; x++ {
-			color := d.matrix[x][y]
-			rgb := ColorMap[color.Name]
-
-			// Write RGB values with space separator, no newline between pixels
-			if x > 0 {
-				if _, err = fmt.Fprint(file, " "); err != nil {
-					return fileError
-				}
-			}
-
-			if _, err = fmt.Fprintf(file, "%d %d %d", rgb.R, rgb.G, rgb.B); err != nil {
-				return fileError
-			}
-		}
-
-		// Only add newline at the end of each row
-		if _, err = fmt.Fprintln(file); err != nil {
-			return fileError
-		}
-	}
-
-	return nil
-}
-
-// min returns the minimum of two integers
-func min(a, b int) int {
-	if a < b {
-		return a
-	}
-	return b
-}
-
-// max returns the maximum of two integers
-func max(a, b int) int {
-	if a > b {
-		return a
-	}
-	return b
-}
+// Package main implements a geometric shape drawing application
+// using Go interfaces. This application allows users to draw
+// various shapes (rectangles, triangles, circles) of different colors
+// on a virtual screen and save the result as a PPM image file.
+//
+// CS 341, Spring 2025
+// Project 5 – Geometry Using Go Interfaces
+// Joel Lau Arrieta
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// RGB represents a color in RGB format with red, green, and blue components
+// Each value ranges from 0 to 255
+// Used for mapping color names to actual RGB values
+// Example: RGB{255, 0, 0} is red
+type RGB struct {
+	R, G, B int // Values range from 0-255
+}
+
+// Color represents a color by its name
+// The name must be one of the predefined colors in the ColorMap,
+// or an inline RGB value of the form "#rrggbb" (see NewColorRGB)
+// The zero Color is the empty color, used for "no fill" or "no outline", and is not valid to draw with
+// Example: NewColor("red"), NewColor("#ff0000")
+type Color struct {
+	name string
+}
+
+// NewColor returns the color with the given name
+// The name is not checked here; drawing with an unknown color returns invalidColor
+func NewColor(name string) Color {
+	return Color{name}
+}
+
+// Name returns the name of the color
+func (c Color) Name() string {
+	return c.name
+}
+
+// SetName changes the name of the color
+// Returns invalidColor and leaves the color unchanged if the name is not a known or inline RGB color
+func (c *Color) SetName(name string) error {
+	if colorUnknown(Color{name}) {
+		return invalidColor
+	}
+	c.name = name
+	return nil
+}
+
+// RGB returns the RGB value of the color
+// Returns invalidColor if the color is unknown
+func (c Color) RGB() (RGB, error) {
+	return colorToRGB(c)
+}
+
+// IsValid returns true if the color is in the ColorMap or is a valid inline RGB color
+func (c Color) IsValid() bool {
+	return !colorUnknown(c)
+}
+
+// Point represents a 2D point in the coordinate system
+// x and y are integer coordinates
+type Point struct {
+	x, y int // x and y coordinates
+}
+
+// ColorMap maps color names to RGB values
+// The application supports the following colors:
+// red, green, blue, yellow, orange, purple, brown, black, white
+var ColorMap = map[string]RGB{
+	"red":    {255, 0, 0},
+	"green":  {0, 255, 0},
+	"blue":   {0, 0, 255},
+	"yellow": {255, 255, 0},
+	"orange": {255, 164, 0},
+	"purple": {128, 0, 128},
+	"brown":  {165, 42, 42},
+	"black":  {0, 0, 0},
+	"white":  {255, 255, 255},
+}
+
+// AddColorNamed adds a color with the given name and RGB components to the ColorMap
+// Returns errColorExists if the name is already in the ColorMap, so built-in colors cannot be
+// overridden, invalidColor for an empty name or one starting with '#' (used by inline colors),
+// or errInvalidRGB if a component is outside [0,255]
+func AddColorNamed(name string, r, g, b int) error {
+	if _, exists := ColorMap[name]; exists {
+		return errColorExists
+	}
+	if name == "" || name[0] == '#' {
+		return invalidColor
+	}
+	for _, v := range []int{r, g, b} {
+		if v != clampChannel(v) {
+			return errInvalidRGB
+		}
+	}
+	ColorMap[name] = RGB{r, g, b}
+	return nil
+}
+
+// GetColorNamed returns the RGB value of the named color in the ColorMap
+// Returns false if there is no color with that name
+func GetColorNamed(name string) (RGB, bool) {
+	rgb, exists := ColorMap[name]
+	return rgb, exists
+}
+
+// ColorNames returns the names in the ColorMap in alphabetical order
+// Used wherever colors are picked by index so results do not depend on map ordering
+func ColorNames() []string {
+	names := make([]string, 0, len(ColorMap))
+	for name := range ColorMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Error types defined for different error cases in the application
+// errOutOfBounds: Used when a shape or pixel is outside the display
+// invalidColor: Used when a color is not in the ColorMap
+// fileError: Used when there is a problem creating or writing to a file
+// errInvalidShape: Used when a shape has invalid or degenerate parameters
+// errDimensionMismatch: Used when two displays or data sets do not have the same size
+// errInvalidDrawMode: Used when a DrawMode is not one of the defined modes
+// errInvalidThickness: Used when a line thickness is less than 1
+// errEmptyDisplay: Used when a display with no pixels is given as a source
+// errInvalidDensity: Used when a density or probability is outside [0,1]
+// errInvalidIterations: Used when an iteration count is too small or too large
+// errInvalidZoom: Used when a zoom factor is not positive
+// errInvalidStep: Used when a grid step is not positive
+// errNilDisplay: Used when a nil display is passed where one is required
+// errInvalidOrientation: Used when a layout orientation is not "vertical" or "horizontal"
+// errUnknownLayer: Used when a scene layer name does not exist
+// errInvalidLayerOrder: Used when a layer order does not list every scene layer exactly once
+// errInvalidQuantize: Used when a color count or palette for quantization is empty
+// errInvalidRadius: Used when a filter radius is less than 1
+// errSelfIntersecting: Used when filling a polygon whose edges cross each other
+// errInvalidTurns: Used when a spiral has fewer than one turn
+// errIncompleteShape: Used when a shape builder is missing a required field
+// errUnknownShapeType: Used when a shape type name is not recognized
+// errMissingParam: Used when a required shape parameter is missing
+// errInvalidParam: Used when a shape parameter has the wrong type
+// errParseShape: Used when a shape description cannot be parsed
+// errUniformDisplay: Used when every pixel of a display has the same luminance
+// errInvalidKernel: Used when a convolution kernel is not a square with an odd side
+// errInvalidLevels: Used when a number of levels per color channel is outside [2,256]
+// errInvalidSplit: Used when a shape cannot be split into the requested number of parts
+// errInvalidProgress: Used when a progress fraction is outside [0,1]
+// errNoSlices: Used when a pie chart has no slices or no positive total
+// errInvalidThreshold: Used when a color channel threshold is outside [0,255]
+// errEmptyPalette: Used when a color palette has no colors
+// errInvalidHeadSize: Used when an arrowhead size is not positive
+// errInvalidCornerRadius: Used when a corner radius is negative or more than half the shorter side
+// errInvalidGap: Used when a crosshair gap is negative or not smaller than the crosshair
+// errUnknownFormat: Used when an image file format is not supported
+// errNilImage: Used when a nil image is passed where one is required
+// errInvalidBlockSize: Used when a block size is less than 1
+// errInvalidEdge: Used when a graph edge refers to a node that does not exist
+// errColorExists: Used when adding a color whose name is already in the ColorMap
+// errInvalidRGB: Used when an RGB component is outside [0,255]
+// errInvalidOpacity: Used when an opacity is outside [0,1]
+// errInvalidDash: Used when a dash or gap length is not positive
+// errArgumentMismatch: Used when two argument lists that must pair up have different lengths
+// errTooManyIterations: Used when an L-system is expanded more than maxLSystemIterations times
+var errOutOfBounds = errors.New("Attempt to draw a figure out of bounds of the screen.")
+var invalidColor = errors.New("Attempt to use an invalid color.")
+var fileError = errors.New("Unable to create PPM file.")
+var errInvalidShape = errors.New("Shape has invalid or degenerate parameters.")
+var errDimensionMismatch = errors.New("Dimensions do not match.")
+var errInvalidDrawMode = errors.New("Unknown draw mode.")
+var errInvalidThickness = errors.New("Line thickness must be at least 1.")
+var errEmptyDisplay = errors.New("Display has zero dimensions.")
+var errInvalidDensity = errors.New("Density must be between 0 and 1.")
+var errInvalidIterations = errors.New("Invalid number of iterations.")
+var errInvalidZoom = errors.New("Zoom must be greater than 0.")
+var errInvalidStep = errors.New("Step must be greater than 0.")
+var errNilDisplay = errors.New("Display is nil.")
+var errInvalidOrientation = errors.New("Orientation must be \"vertical\" or \"horizontal\".")
+var errUnknownLayer = errors.New("Layer does not exist.")
+var errInvalidLayerOrder = errors.New("Layer order must list every layer exactly once.")
+var errInvalidQuantize = errors.New("Quantization needs at least one color.")
+var errInvalidRadius = errors.New("Radius must be at least 1.")
+var errSelfIntersecting = errors.New("Cannot fill a self-intersecting polygon.")
+var errInvalidTurns = errors.New("Spiral must have at least one turn.")
+var errIncompleteShape = errors.New("Shape is missing required parameters.")
+var errUnknownShapeType = errors.New("Unknown shape type.")
+var errMissingParam = errors.New("Missing shape parameter.")
+var errInvalidParam = errors.New("Shape parameter has the wrong type.")
+var errParseShape = errors.New("Unable to parse shape description.")
+var errUniformDisplay = errors.New("Display has only one luminance level.")
+var errInvalidKernel = errors.New("Kernel must be a square with an odd side length.")
+var errInvalidLevels = errors.New("Levels must be between 2 and 256.")
+var errInvalidSplit = errors.New("Cannot split the shape into that many parts.")
+var errInvalidProgress = errors.New("Progress must be between 0 and 1.")
+var errNoSlices = errors.New("Pie chart needs slices with a positive total.")
+var errInvalidThreshold = errors.New("Threshold must be between 0 and 255.")
+var errEmptyPalette = errors.New("Palette must contain at least one color.")
+var errInvalidHeadSize = errors.New("Arrowhead size must be greater than 0.")
+var errInvalidCornerRadius = errors.New("Corner radius must be between 0 and half the shorter side.")
+var errInvalidGap = errors.New("Gap radius must be at least 0 and smaller than the crosshair size.")
+var errUnknownFormat = errors.New("Unknown image format.")
+var errNilImage = errors.New("Image is nil.")
+var errInvalidBlockSize = errors.New("Block size must be at least 1.")
+var errInvalidEdge = errors.New("Edge refers to a node that does not exist.")
+var errColorExists = errors.New("Color name is already defined.")
+var errInvalidRGB = errors.New("RGB components must be between 0 and 255.")
+var errInvalidOpacity = errors.New("Opacity must be between 0 and 1.")
+var errInvalidDash = errors.New("Dash and gap lengths must be greater than 0.")
+var errArgumentMismatch = errors.New("Argument lengths do not match.")
+var errTooManyIterations = errors.New("Too many L-system iterations.")
+
+// DrawError reports the pixel at which drawing failed
+// X, Y: The pixel coordinates, Wrapped: The underlying error (errOutOfBounds or invalidColor)
+type DrawError struct {
+	X, Y    int   // Pixel that could not be drawn
+	Wrapped error // Reason the pixel could not be drawn
+}
+
+// Error returns the wrapped error prefixed with the pixel coordinates
+func (e *DrawError) Error() string {
+	return fmt.Sprintf("drawPixel(%d,%d): %v", e.X, e.Y, e.Wrapped)
+}
+
+// Unwrap returns the wrapped error so that errors.Is matches the sentinel errors
+func (e *DrawError) Unwrap() error {
+	return e.Wrapped
+}
+
+// DrawMode selects how a shape is rendered
+// DrawFill: Fill the interior of the shape
+// DrawOutline: Draw only the boundary of the shape
+// DrawBoth: Fill the shape, then draw its boundary on top
+type DrawMode int
+
+const (
+	DrawFill DrawMode = iota
+	DrawOutline
+	DrawBoth
+)
+
+// DrawDefault is the mode used when no particular mode is requested
+const DrawDefault = DrawFill
+
+// valid returns true if the mode is one of the defined modes
+func (m DrawMode) valid() bool {
+	return m == DrawFill || m == DrawOutline || m == DrawBoth
+}
+
+// fills returns true if the mode fills the interior of a shape
+func (m DrawMode) fills() bool {
+	return m == DrawFill || m == DrawBoth
+}
+
+// outlines returns true if the mode draws the boundary of a shape
+func (m DrawMode) outlines() bool {
+	return m == DrawOutline || m == DrawBoth
+}
+
+// geometry interface defines methods that all shapes must implement
+// DrawOn: Draws the shape on the provided screen in the given mode
+// draw: Deprecated alias of DrawOn
+// String: Returns a string representation of the shape (fmt.Stringer)
+// printShape: Deprecated alias of String
+// Vertices: Returns the corner points of the shape
+// Centroid: Returns the center point of the shape
+// BoundingBox: Returns the smallest box of pixels around the shape
+// Contains: Reports whether a point lies inside the shape
+// Intersects: Reports whether the shape overlaps another shape
+type geometry interface {
+	// DrawOn draws the shape on the provided screen in the given mode
+	DrawOn(scn screen, mode DrawMode) (err error)
+
+	// draw is the same as DrawOn
+	// Deprecated: Use DrawOn
+	draw(scn screen, mode DrawMode) (err error)
+
+	// String returns a string representation of the shape
+	String() string
+
+	// printShape returns the same description as String
+	// Deprecated: Use String, or format the shape with %v
+	printShape() (s string)
+
+	// Vertices returns the corner points of the shape in order
+	Vertices() []Point
+
+	// Centroid returns the center point of the shape
+	Centroid() Point
+
+	// BoundingBox returns the smallest box of pixels containing the shape
+	BoundingBox() Box
+
+	// Contains returns true if the point lies inside the shape or on its edge
+	Contains(p Point) bool
+
+	// Intersects returns true if the shape overlaps the other shape
+	Intersects(other geometry) bool
+
+	// Validate returns errInvalidShape if the shape is degenerate
+	Validate() error
+
+	// Normalize returns the shape in a canonical form that draws the same pixels
+	Normalize() geometry
+
+	// Offset returns a copy of the shape moved by delta
+	Offset(delta Point) geometry
+
+	// OffsetBy returns a copy of the shape moved by dx horizontally and dy vertically
+	OffsetBy(dx, dy int) geometry
+
+	// DrawContext draws the shape like DrawOn in the default mode, stopping early with
+	// ctx.Err() once the context is cancelled or its deadline has passed
+	DrawContext(ctx context.Context, scn screen) error
+
+	// DrawAA draws the shape like DrawOn in the default mode, antialiased by supersampling
+	DrawAA(scn screen) error
+}
+
+// Rectangle struct represents a rectangle defined by lower-left and upper-right points
+// ll: Lower-left corner, ur: Upper-right corner, c: Fill color, stroke: Outline color
+// Thickness: Outline width in pixels, at least 1
+type Rectangle struct {
+	ll        Point // Lower-left corner
+	ur        Point // Upper-right corner
+	c         Color // Fill color
+	stroke    Color // Outline color, empty for no separate outline
+	Thickness int   // Outline width in pixels, at least 1
+}
+
+// Triangle struct represents a triangle defined by three points
+// pt0, pt1, pt2: The three vertices, c: Fill color, stroke: Outline color
+// Thickness: Outline width in pixels, at least 1
+type Triangle struct {
+	pt0       Point // First point
+	pt1       Point // Second point
+	pt2       Point // Third point
+	c         Color // Fill color
+	stroke    Color // Outline color, empty for no separate outline
+	Thickness int   // Outline width in pixels, at least 1
+}
+
+// Circle struct represents a circle defined by center point and radius
+// center: Center point, r: Radius, c: Fill color, stroke: Outline color
+// Thickness: Outline width in pixels, at least 1
+type Circle struct {
+	center    Point // Center point
+	r         int   // Radius
+	c         Color // Fill color
+	stroke    Color // Outline color, empty for no separate outline
+	Thickness int   // Outline width in pixels, at least 1
+}
+
+// NewRectangle creates a Rectangle of the given fill color from ll to ur
+func NewRectangle(ll, ur Point, c Color) Rectangle {
+	return Rectangle{ll: ll, ur: ur, c: c, Thickness: 1}
+}
+
+// NewTriangle creates a Triangle of the given fill color through the three points
+func NewTriangle(pt0, pt1, pt2 Point, c Color) Triangle {
+	return Triangle{pt0: pt0, pt1: pt1, pt2: pt2, c: c, Thickness: 1}
+}
+
+// NewCircle creates a Circle of the given fill color and radius r around center
+func NewCircle(center Point, r int, c Color) Circle {
+	return Circle{center: center, r: r, c: c, Thickness: 1}
+}
+
+// screen interface defines methods that any display screen must implement
+// Used to abstract the display implementation
+// initialize: Create a screen with given dimensions
+// getMaxXY: Get the maximum x and y dimensions
+// drawPixel: Color a pixel at a location
+// getPixel: Get the color of a pixel
+// clearScreen: Reset all pixels to white
+// screenShot: Save the screen to a PPM file
+type screen interface {
+	initialize(x, y int)
+	getMaxXY() (x, y int)
+	drawPixel(x, y int, c Color) (err error)
+	getPixel(x, y int) (c Color, err error)
+	clearScreen()
+	screenShot(f string) (err error)
+}
+
+// BoundsMode selects what a Display does with pixels drawn outside of it
+// BoundsError: Return errOutOfBounds (the default)
+// BoundsClip: Silently skip the pixel
+// BoundsWrap: Wrap the pixel around to the opposite edge
+type BoundsMode int
+
+const (
+	BoundsError BoundsMode = iota
+	BoundsClip
+	BoundsWrap
+)
+
+// Display struct implements the screen interface
+// maxX, maxY: Dimensions of the display
+// matrix: 2D slice representing pixel colors
+// bounds: What to do with pixels drawn outside the display
+// pending: Shapes queued by DrawWithZ, waiting for Commit
+type Display struct {
+	maxX    int        // Width of the display
+	maxY    int        // Height of the display
+	matrix  [][]Color  // 2D slice representing pixel colors
+	bounds  BoundsMode // Out of bounds behavior of drawPixel
+	pending []zShape   // Queued shapes sorted by z
+}
+
+// colorUnknown checks if a color is not defined in the ColorMap
+// and is not a valid inline RGB color
+// Returns true if the color is unknown
+func colorUnknown(c Color) bool {
+	_, err := colorToRGB(c)
+	return err != nil
+}
+
+// colorToRGB returns the RGB value of a color
+// Named colors are looked up in the ColorMap, inline colors are decoded from "#rrggbb"
+// Returns invalidColor if the color is neither
+func colorToRGB(c Color) (rgb RGB, err error) {
+	if rgb, exists := ColorMap[c.name]; exists {
+		return rgb, nil
+	}
+	if len(c.name) != 7 || c.name[0] != '#' {
+		return RGB{}, invalidColor
+	}
+	v, err := strconv.ParseUint(c.name[1:], 16, 32)
+	if err != nil {
+		return RGB{}, invalidColor
+	}
+	return RGB{int(v >> 16 & 0xff), int(v >> 8 & 0xff), int(v & 0xff)}, nil
+}
+
+// NewColorRGB creates an inline Color holding the given RGB components
+// Each component is clamped to the 0-255 range
+// Example: NewColorRGB(255, 0, 0) is NewColor("#ff0000")
+func NewColorRGB(r, g, b int) Color {
+	return Color{fmt.Sprintf("#%02x%02x%02x", clampChannel(r), clampChannel(g), clampChannel(b))}
+}
+
+// blendRGB returns the inline Color alpha of the way from dst to src
+// An alpha of 0 gives dst and an alpha of 1 gives src
+func blendRGB(dst, src RGB, alpha float64) Color {
+	mix := func(a, b int) int { return int(math.Round(lerp(float64(a), float64(b), alpha))) }
+	return NewColorRGB(mix(dst.R, src.R), mix(dst.G, src.G), mix(dst.B, src.B))
+}
+
+// clampChannel clamps a color component to the 0-255 range
+func clampChannel(v int) int {
+	return max(0, min(v, 255))
+}
+
+// brushSize returns the pixel width used to draw an outline of the given thickness
+// Returns errInvalidThickness for a thickness less than 1
+func brushSize(thickness int) (size int, err error) {
+	if thickness < 1 {
+		return 0, errInvalidThickness
+	}
+	return thickness, nil
+}
+
+// thickScreen wraps a screen so that every pixel drawn becomes a size x size square
+// Parts of the square that fall outside a bounds-checking screen are skipped,
+// other screens handle them in their own drawPixel
+type thickScreen struct {
+	screen
+	size int // Width of the square drawn for each pixel
+}
+
+// drawPixel draws a size x size square of color c centered on (x,y)
+func (ts thickScreen) drawPixel(x, y int, c Color) (err error) {
+	strict := checksBounds(ts.screen)
+	lo := -(ts.size - 1) / 2
+	for dx := lo; dx < lo+ts.size; dx++ {
+		for dy := lo; dy < lo+ts.size; dy++ {
+			px, py := x+dx, y+dy
+			if strict && !(Point{px, py}).InBounds(ts.screen) {
+				continue
+			}
+			if err = ts.screen.drawPixel(px, py, c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// withThickness returns a screen that draws with a brush of the given size
+// A size of 1 returns scn unchanged
+func withThickness(scn screen, size int) screen {
+	if size <= 1 {
+		return scn
+	}
+	return thickScreen{scn, size}
+}
+
+// paintColors works out which colors a shape with the given fill and stroke is drawn with
+// The fill is used only when the mode fills; the stroke is always drawn when set,
+// and outline modes fall back to the fill color when there is no stroke
+// An empty result color means that part of the shape is not drawn
+// Returns invalidColor if both colors are empty or a non-empty color is unknown
+func paintColors(fill, stroke Color, mode DrawMode) (f, s Color, err error) {
+	if fill == (Color{}) && stroke == (Color{}) {
+		return Color{}, Color{}, invalidColor
+	}
+	if (fill != Color{} && colorUnknown(fill)) || (stroke != Color{} && colorUnknown(stroke)) {
+		return Color{}, Color{}, invalidColor
+	}
+	if mode.fills() {
+		f = fill
+	}
+	s = stroke
+	if s == (Color{}) && mode.outlines() {
+		s = fill
+	}
+	return f, s, nil
+}
+
+// describeColors returns the fill and stroke part of a shape description
+// Empty colors are written as "none"
+func describeColors(fill, stroke Color) string {
+	name := func(c Color) string {
+		if c == (Color{}) {
+			return "none"
+		}
+		return c.name
+	}
+	return fmt.Sprintf("fill %s, stroke %s", name(fill), name(stroke))
+}
+
+// Distance returns the Euclidean distance between two points
+func (p Point) Distance(q Point) float64 {
+	return math.Hypot(float64(p.x-q.x), float64(p.y-q.y))
+}
+
+// Clamp returns the point moved to the nearest point of the rectangle from (minX,minY) to (maxX,maxY)
+func (p Point) Clamp(minX, minY, maxX, maxY int) Point {
+	return Point{max(minX, min(p.x, maxX)), max(minY, min(p.y, maxY))}
+}
+
+// Lerp returns the point t of the way from p to other, rounded to the nearest pixel
+// t = 0 gives p and t = 1 gives other
+func (p Point) Lerp(other Point, t float64) Point {
+	return Point{int(math.Round(lerp(float64(p.x), float64(other.x), t))), int(math.Round(lerp(float64(p.y), float64(other.y), t)))}
+}
+
+// InBounds checks if the point lies on the screen
+// Returns true if the point is inside the screen, false otherwise.
+func (p Point) InBounds(scn screen) bool {
+	xMax, yMax := scn.getMaxXY()
+	return p.x >= 0 && p.x < xMax && p.y >= 0 && p.y < yMax
+}
+
+// anyOutOfBounds checks if any of the points would go out of bounds of the screen
+// Always false for screens that clip out of bounds pixels themselves (see checksBounds)
+func anyOutOfBounds(scn screen, points ...Point) bool {
+	if !checksBounds(scn) {
+		return false
+	}
+	for _, p := range points {
+		if !p.InBounds(scn) {
+			return true
+		}
+	}
+	return false
+}
+
+// clipper is implemented by screens that may skip out of bounds pixels instead of failing
+type clipper interface {
+	clips() bool
+}
+
+// checksBounds returns true if shapes must lie entirely inside scn to be drawn on it
+// Screens that report clips() handle out of bounds pixels in drawPixel instead
+func checksBounds(scn screen) bool {
+	c, ok := scn.(clipper)
+	return !ok || !c.clips()
+}
+
+// discarder is implemented by screens that may silently drop the pixels outside of them
+// Unlike clipper it leaves out screens that wrap those pixels around instead
+type discarder interface {
+	discards() bool
+}
+
+// clipArea returns the only pixels a shape drawn on scn can change, for screens that drop
+// the pixels outside of them, so that fills can skip the rest of their area
+// ok is false if every pixel of the shape has to be drawn
+func clipArea(scn screen) (area Box, ok bool) {
+	if dc, isDiscarder := scn.(discarder); !isDiscarder || !dc.discards() {
+		return Box{}, false
+	}
+	maxX, maxY := scn.getMaxXY()
+	return Box{Point{0, 0}, Point{maxX - 1, maxY - 1}}, true
+}
+
+// clipScreen wraps a screen so that pixels outside of it are silently skipped
+type clipScreen struct {
+	screen
+}
+
+// drawPixel draws the pixel if it lies on the wrapped screen and ignores it otherwise
+func (cs clipScreen) drawPixel(x, y int, c Color) (err error) {
+	if !(Point{x, y}).InBounds(cs.screen) {
+		return nil
+	}
+	return cs.screen.drawPixel(x, y, c)
+}
+
+// clips is the clipScreen implementation of the clipper interface
+func (cs clipScreen) clips() bool {
+	return true
+}
+
+// discards is the clipScreen implementation of the discarder interface
+func (cs clipScreen) discards() bool {
+	return true
+}
+
+// callbackScreen wraps a screen so that before and after are called around every pixel drawn
+// A nil callback is skipped
+type callbackScreen struct {
+	screen
+	before func(x, y int, c Color) // Called before the pixel is drawn
+	after  func(x, y int, c Color) // Called once the pixel has been drawn
+}
+
+// drawPixel calls before, draws the pixel on the wrapped screen and then calls after
+// after is not called if the wrapped screen returns an error
+func (cs callbackScreen) drawPixel(x, y int, c Color) (err error) {
+	if cs.before != nil {
+		cs.before(x, y, c)
+	}
+	if err = cs.screen.drawPixel(x, y, c); err != nil {
+		return err
+	}
+	if cs.after != nil {
+		cs.after(x, y, c)
+	}
+	return nil
+}
+
+// clips is the callbackScreen implementation of the clipper interface
+// Bounds are handled the same way as on the wrapped screen
+func (cs callbackScreen) clips() bool {
+	return !checksBounds(cs.screen)
+}
+
+// shadeScreen wraps a screen so that every pixel gets the color returned by shade
+// instead of the color it was drawn with
+type shadeScreen struct {
+	screen
+	shade func(x, y int) Color // Color of the pixel at (x,y)
+}
+
+// drawPixel draws the pixel on the wrapped screen with the color from shade
+func (ss shadeScreen) drawPixel(x, y int, _ Color) (err error) {
+	return ss.screen.drawPixel(x, y, ss.shade(x, y))
+}
+
+// clips is the shadeScreen implementation of the clipper interface
+// Bounds are handled the same way as on the wrapped screen
+func (ss shadeScreen) clips() bool {
+	return !checksBounds(ss.screen)
+}
+
+// outOfBoundsCircle checks if any part of the circle's bounding box lies outside the screen
+// Returns true if the circle would go out of bounds, false otherwise.
+func outOfBoundsCircle(center Point, r int, scn screen) bool {
+	maxX, maxY := scn.getMaxXY()
+	return center.x-r < 0 || center.y-r < 0 || center.x+r >= maxX || center.y+r >= maxY
+}
+
+// cross returns the z component of the cross product of the vectors o->a and o->b
+// Positive for a counter-clockwise turn, negative for clockwise, zero if the points are collinear
+func cross(o, a, b Point) int {
+	return (a.x-o.x)*(b.y-o.y) - (a.y-o.y)*(b.x-o.x)
+}
+
+// ConvexHull computes the convex hull of a set of points using Andrew's monotone chain
+// (a variant of the Graham scan)
+// Returns the hull vertices in counter-clockwise order, without collinear points,
+// or nil if there are fewer than 3 non-collinear points
+func ConvexHull(points []Point) []Point {
+	pts := append([]Point(nil), points...)
+	sort.Slice(pts, func(i, j int) bool {
+		if pts[i].x != pts[j].x {
+			return pts[i].x < pts[j].x
+		}
+		return pts[i].y < pts[j].y
+	})
+	if len(pts) < 3 {
+		return nil
+	}
+
+	// Build the lower hull left to right, then the upper hull right to left
+	hull := make([]Point, 0, 2*len(pts))
+	for _, p := range pts {
+		for len(hull) >= 2 && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	lower := len(hull) + 1
+	for i := len(pts) - 2; i >= 0; i-- {
+		p := pts[i]
+		for len(hull) >= lower && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+
+	// The last point repeats the first one
+	hull = hull[:len(hull)-1]
+	if len(hull) < 3 {
+		return nil
+	}
+	return hull
+}
+
+// interpolate() is a helper function
+// Linearly interpolates between two points (l0, d0) and (l1, d1)
+// Returns a slice of integer values representing the interpolated points
+func interpolate(l0, d0, l1, d1 int) (values []int) {
+	a := float64(d1-d0) / float64(l1-l0)
+	d := float64(d0)
+
+	count := l1 - l0 + 1
+	for ; count > 0; count-- {
+		values = append(values, int(d))
+		d = d + a
+	}
+	return
+}
+
+// DrawOn is the Triangle implementation of the geometry.DrawOn method
+// Fills the triangle using scanline interpolation and/or draws its three edges
+// Returns errInvalidShape for collinear vertices, or an error if the triangle is out of bounds or if the color is invalid
+func (tri Triangle) DrawOn(scn screen, mode DrawMode) (err error) {
+	if !mode.valid() {
+		return errInvalidDrawMode
+	}
+	if err = tri.Validate(); err != nil {
+		return err
+	}
+
+	// Check if drawing this triangle would cause either error
+	if anyOutOfBounds(scn, tri.pt0, tri.pt1, tri.pt2) {
+		return errOutOfBounds
+	}
+	fill, stroke, err := paintColors(tri.c, tri.stroke, mode)
+	if err != nil {
+		return err
+	}
+	size, err := brushSize(tri.Thickness)
+	if err != nil {
+		return err
+	}
+
+	if fill != (Color{}) {
+		if err = tri.fill(scn, fill); err != nil {
+			return err
+		}
+	}
+	if stroke != (Color{}) {
+		brush := withThickness(scn, size)
+		for _, edge := range [][2]Point{{tri.pt0, tri.pt1}, {tri.pt1, tri.pt2}, {tri.pt2, tri.pt0}} {
+			if err = drawLine(brush, edge[0], edge[1], stroke); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fill draws the filled triangle in color c using scanline interpolation
+// Returns the first error reported by the screen's drawPixel
+func (tri Triangle) fill(scn screen, c Color) (err error) {
+	// Sort the points so that y0 <= y1 <= y2, comparing the points as they are swapped
+	if tri.pt1.y < tri.pt0.y {
+		tri.pt1, tri.pt0 = tri.pt0, tri.pt1
+	}
+	if tri.pt2.y < tri.pt0.y {
+		tri.pt2, tri.pt0 = tri.pt0, tri.pt2
+	}
+	if tri.pt2.y < tri.pt1.y {
+		tri.pt2, tri.pt1 = tri.pt1, tri.pt2
+	}
+	x0, y0, x1, y1, x2, y2 := tri.pt0.x, tri.pt0.y, tri.pt1.x, tri.pt1.y, tri.pt2.x, tri.pt2.y
+
+	// Interpolate the x-coordinates for the triangle edges
+	x01 := interpolate(y0, x0, y1, x1)
+	x12 := interpolate(y1, x1, y2, x2)
+	x02 := interpolate(y0, x0, y2, x2)
+
+	// Concatenate the short sides
+	x012 := append(x01[:len(x01)-1], x12...)
+
+	// Determine which is left and which is right
+	var x_left, x_right []int
+	m := len(x012) / 2
+	if x02[m] < x012[m] {
+		x_left = x02
+		x_right = x012
+	} else {
+		x_left = x012
+		x_right = x02
+	}
+
+	// Draw the horizontal segments (scanlines), skipping the parts a clipping screen would drop
+	yStart, yEnd, xMin, xMax := y0, y2, math.MinInt, math.MaxInt
+	if area, ok := clipArea(scn); ok {
+		yStart, yEnd = max(y0, area.Min.y), min(y2, area.Max.y)
+		xMin, xMax = area.Min.x, area.Max.x
+	}
+	for y := yStart; y <= yEnd; y++ {
+		for x := max(x_left[y-y0], xMin); x <= min(x_right[y-y0], xMax); x++ {
+			if err = scn.drawPixel(x, y, c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// insideCircle() is a helper function
+// Returns true if the tile point is inside the circle with given center and radius
+func insideCircle(center, tile Point, r float64) (inside bool) {
+	var dx float64 = float64(center.x - tile.x)
+	var dy float64 = float64(center.y - tile.y)
+	var distance float64 = math.Sqrt(dx*dx + dy*dy)
+	return distance <= r
+}
+
+// DrawOn is the Rectangle implementation of the geometry.DrawOn method
+// It fills in every pixel inside the rectangle and/or its border with the specified color
+// Returns errInvalidShape for an empty rectangle, or an error if the rectangle is out of bounds or if the color is invalid
+func (r Rectangle) DrawOn(scn screen, mode DrawMode) (err error) {
+	if !mode.valid() {
+		return errInvalidDrawMode
+	}
+	if err = r.Validate(); err != nil {
+		return err
+	}
+
+	// Check if rectangle is out of bounds
+	if anyOutOfBounds(scn, r.ll, r.ur) {
+		return errOutOfBounds
+	}
+	fill, stroke, err := paintColors(r.c, r.stroke, mode)
+	if err != nil {
+		return err
+	}
+	size, err := brushSize(r.Thickness)
+	if err != nil {
+		return err
+	}
+
+	// Fill in rectangle by drawing each pixel (exclusive upper bounds)
+	// On a clipping screen only the part of the rectangle on the screen is visited
+	if fill != (Color{}) {
+		x0, y0, x1, y1 := r.ll.x, r.ll.y, r.ur.x, r.ur.y
+		if area, ok := clipArea(scn); ok {
+			x0, y0 = max(x0, area.Min.x), max(y0, area.Min.y)
+			x1, y1 = min(x1, area.Max.x+1), min(y1, area.Max.y+1)
+		}
+		for x := x0; x < x1; x++ {
+			for y := y0; y < y1; y++ {
+				err = scn.drawPixel(x, y, fill)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if stroke != (Color{}) {
+		return r.outline(withThickness(scn, size), stroke)
+	}
+	return nil
+}
+
+// outline draws the border pixels of the area covered by the filled rectangle in color c
+func (r Rectangle) outline(scn screen, c Color) (err error) {
+	if r.ll.x >= r.ur.x || r.ll.y >= r.ur.y {
+		return nil
+	}
+	for x := r.ll.x; x < r.ur.x; x++ {
+		if err = scn.drawPixel(x, r.ll.y, c); err != nil {
+			return err
+		}
+		if err = scn.drawPixel(x, r.ur.y-1, c); err != nil {
+			return err
+		}
+	}
+	for y := r.ll.y; y < r.ur.y; y++ {
+		if err = scn.drawPixel(r.ll.x, y, c); err != nil {
+			return err
+		}
+		if err = scn.drawPixel(r.ur.x-1, y, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DrawOn is the Circle implementation of the geometry.DrawOn method
+// Draws a filled circle using the insideCircle helper and/or its outline
+// Only draws pixels within the display bounds
+// Returns errInvalidShape for a radius <= 0, or an error if the circle is out of bounds or if the color is invalid
+func (c Circle) DrawOn(scn screen, mode DrawMode) (err error) {
+	if !mode.valid() {
+		return errInvalidDrawMode
+	}
+	if err = c.Validate(); err != nil {
+		return err
+	}
+
+	if checksBounds(scn) && outOfBoundsCircle(c.center, c.r, scn) {
+		return errOutOfBounds
+	}
+	fill, stroke, err := paintColors(c.c, c.stroke, mode)
+	if err != nil {
+		return err
+	}
+	size, err := brushSize(c.Thickness)
+	if err != nil {
+		return err
+	}
+
+	if fill != (Color{}) {
+		if err = circleFill(scn, c.center, c.r, fill); err != nil {
+			return err
+		}
+	}
+	if stroke != (Color{}) {
+		return circleOutline(withThickness(scn, size), c.center, c.r, stroke)
+	}
+	return
+}
+
+// circleFill draws a filled circle by scanning its bounding box with the insideCircle helper
+// Pixels outside the screen are left to the screen's drawPixel
+// Returns the first error reported by the screen's drawPixel
+func circleFill(scn screen, center Point, r int, c Color) (err error) {
+	for y := center.y - r; y <= center.y+r; y++ {
+		for x := center.x - r; x <= center.x+r; x++ {
+			if !insideCircle(center, Point{x, y}, float64(r)) {
+				continue
+			}
+			if err = scn.drawPixel(x, y, c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// DrawCircleOutline draws only the circumference of the circle centered at (cx,cy) with radius r
+// Produces the same pixels as a Circle drawn with DrawOutline
+// Returns errInvalidShape for a negative radius, errOutOfBounds if the circle does not fit
+// on the display, or invalidColor if the color is not recognized
+func (d *Display) DrawCircleOutline(cx, cy, r int, c Color) (err error) {
+	if err = d.checkCircle(cx, cy, r, c); err != nil {
+		return err
+	}
+	return circleOutline(d, Point{cx, cy}, r, c)
+}
+
+// DrawCircleFilled draws the filled circle centered at (cx,cy) with radius r
+// Produces the same pixels as a Circle drawn with DrawFill
+// Returns the same errors as DrawCircleOutline
+func (d *Display) DrawCircleFilled(cx, cy, r int, c Color) (err error) {
+	if err = d.checkCircle(cx, cy, r, c); err != nil {
+		return err
+	}
+	return circleFill(d, Point{cx, cy}, r, c)
+}
+
+// checkCircle validates the parameters of the circle drawing primitives
+func (d *Display) checkCircle(cx, cy, r int, c Color) error {
+	if r < 0 {
+		return errInvalidShape
+	}
+	if checksBounds(d) && outOfBoundsCircle(Point{cx, cy}, r, d) {
+		return errOutOfBounds
+	}
+	if colorUnknown(c) {
+		return invalidColor
+	}
+	return nil
+}
+
+// circleOutline draws the circumference of a circle using Bresenham's midpoint algorithm
+// Eight symmetric points are plotted per step, so this runs in O(r)
+func circleOutline(scn screen, center Point, r int, c Color) (err error) {
+	x, y, p := r, 0, 1-r
+	for x >= y {
+		for _, o := range [8]Point{{x, y}, {y, x}, {-y, x}, {-x, y}, {-x, -y}, {-y, -x}, {y, -x}, {x, -y}} {
+			if err = scn.drawPixel(center.x+o.x, center.y+o.y, c); err != nil {
+				return err
+			}
+		}
+		y++
+		if p <= 0 {
+			p += 2*y + 1
+		} else {
+			x--
+			p += 2*y - 2*x + 1
+		}
+	}
+	return nil
+}
+
+// String is the Rectangle implementation of the fmt.Stringer interface
+// Returns a string description of the rectangle with its coordinates and colors
+func (r Rectangle) String() string {
+	return fmt.Sprintf("Rectangle: (%d,%d) to (%d,%d), %s",
+		r.ll.x, r.ll.y, r.ur.x, r.ur.y, describeColors(r.c, r.stroke))
+}
+
+// String is the Triangle implementation of the fmt.Stringer interface
+// Returns a string description of the triangle with its coordinates and colors
+func (t Triangle) String() string {
+	return fmt.Sprintf("Triangle: (%d,%d), (%d,%d), (%d,%d), %s",
+		t.pt0.x, t.pt0.y, t.pt1.x, t.pt1.y, t.pt2.x, t.pt2.y, describeColors(t.c, t.stroke))
+}
+
+// String is the Circle implementation of the fmt.Stringer interface
+// Returns a string description of the circle with its center, radius and colors
+func (c Circle) String() string {
+	return fmt.Sprintf("Circle: centered around (%d,%d) with radius %d, %s",
+		c.center.x, c.center.y, c.r, describeColors(c.c, c.stroke))
+}
+
+// WithFill returns a copy of the rectangle with the given fill color
+func (r Rectangle) WithFill(c Color) Rectangle {
+	r.c = c
+	return r
+}
+
+// WithStroke returns a copy of the rectangle with the given outline color
+func (r Rectangle) WithStroke(c Color) Rectangle {
+	r.stroke = c
+	return r
+}
+
+// WithFill returns a copy of the triangle with the given fill color
+func (t Triangle) WithFill(c Color) Triangle {
+	t.c = c
+	return t
+}
+
+// WithStroke returns a copy of the triangle with the given outline color
+func (t Triangle) WithStroke(c Color) Triangle {
+	t.stroke = c
+	return t
+}
+
+// WithFill returns a copy of the circle with the given fill color
+func (c Circle) WithFill(fill Color) Circle {
+	c.c = fill
+	return c
+}
+
+// WithStroke returns a copy of the circle with the given outline color
+func (c Circle) WithStroke(stroke Color) Circle {
+	c.stroke = stroke
+	return c
+}
+
+// circleVertexCount is the number of points used to approximate a circle as a polygon
+// 36 points gives one vertex every 10 degrees
+const circleVertexCount = 36
+
+// Vertices is the Rectangle implementation of the geometry.Vertices method
+// Returns the four corners in counter-clockwise order starting at the lower-left corner
+func (r Rectangle) Vertices() []Point {
+	return []Point{r.ll, {r.ur.x, r.ll.y}, r.ur, {r.ll.x, r.ur.y}}
+}
+
+// Vertices is the Triangle implementation of the geometry.Vertices method
+// Returns the three points as given
+func (t Triangle) Vertices() []Point {
+	return []Point{t.pt0, t.pt1, t.pt2}
+}
+
+// Vertices is the Circle implementation of the geometry.Vertices method
+// Returns circleVertexCount points around the circumference
+func (c Circle) Vertices() []Point {
+	return c.VerticesN(circleVertexCount)
+}
+
+// VerticesN returns n evenly spaced points around the circumference of the circle,
+// starting at angle 0 and rounded to the nearest pixel
+func (c Circle) VerticesN(n int) []Point {
+	points := make([]Point, 0, max(n, 0))
+	for i := 0; i < n; i++ {
+		theta := 2 * math.Pi * float64(i) / float64(n)
+		points = append(points, Point{
+			c.center.x + int(math.Round(float64(c.r)*math.Cos(theta))),
+			c.center.y + int(math.Round(float64(c.r)*math.Sin(theta))),
+		})
+	}
+	return points
+}
+
+// Centroid is the Rectangle implementation of the geometry.Centroid method
+// Returns the midpoint between the two corners
+func (r Rectangle) Centroid() Point {
+	return Point{(r.ll.x + r.ur.x) / 2, (r.ll.y + r.ur.y) / 2}
+}
+
+// Centroid is the Circle implementation of the geometry.Centroid method
+// Returns the center of the circle
+func (c Circle) Centroid() Point {
+	return c.center
+}
+
+// initialize creates and initializes a display with the specified dimensions
+// Sets all pixels to white (the default color)
+func (d *Display) initialize(x, y int) {
+	d.maxX = x
+	d.maxY = y
+	d.matrix = make([][]Color, x)
+	for i := range d.matrix {
+		d.matrix[i] = make([]Color, y)
+		for j := range d.matrix[i] {
+			d.matrix[i][j] = NewColor("white") // Initialize to white
+		}
+	}
+}
+
+// Clone returns a new display with the same dimensions and pixels as the receiver
+// The copy is fully independent: drawing on one does not affect the other
+func (d *Display) Clone() *Display {
+	c := &Display{}
+	c.initialize(d.maxX, d.maxY)
+	for x := range d.matrix {
+		copy(c.matrix[x], d.matrix[x])
+	}
+	return c
+}
+
+// newCoverageDisplay returns a display of the given size whose pixels are all the empty Color
+// Drawing on it records which pixels a shape covers, even where the shape is white
+func newCoverageDisplay(x, y int) *Display {
+	d := &Display{maxX: x, maxY: y, matrix: make([][]Color, x)}
+	for i := range d.matrix {
+		d.matrix[i] = make([]Color, y)
+	}
+	return d
+}
+
+// getMaxXY returns the width and height dimensions of the display
+func (d *Display) getMaxXY() (x, y int) {
+	return d.maxX, d.maxY
+}
+
+// drawPixel sets the color of a pixel at coordinates (x,y)
+// Coordinates outside the display are handled according to the display's BoundsMode
+// Returns errOutOfBounds error if the coordinates are outside the display in BoundsError mode
+// Returns invalidColor error if the specified color is not recognized
+func (d *Display) drawPixel(x, y int, c Color) (err error) {
+	// Check if pixel is out of bounds
+	if x < 0 || y < 0 || x >= d.maxX || y >= d.maxY {
+		switch {
+		case d.bounds == BoundsClip:
+			return nil
+		case d.bounds == BoundsWrap && d.maxX > 0 && d.maxY > 0:
+			x, y = mod(x, d.maxX), mod(y, d.maxY)
+		default:
+			return &DrawError{x, y, errOutOfBounds}
+		}
+	}
+
+	// Check if color is valid
+	if colorUnknown(c) {
+		return &DrawError{x, y, invalidColor}
+	}
+
+	// Draw the pixel - store directly
+	d.matrix[x][y] = c
+	return nil
+}
+
+// SetBoundsMode changes how pixels drawn outside the display are handled
+// In BoundsClip and BoundsWrap modes shapes no longer need to fit on the display
+func (d *Display) SetBoundsMode(m BoundsMode) {
+	d.bounds = m
+}
+
+// clips is the Display implementation of the clipper interface
+// Returns true unless the display is in BoundsError mode
+func (d *Display) clips() bool {
+	return d.bounds != BoundsError
+}
+
+// discards is the Display implementation of the discarder interface
+// Returns true only in BoundsClip mode
+func (d *Display) discards() bool {
+	return d.bounds == BoundsClip
+}
+
+// getPixel retrieves the color of a pixel at coordinates (x,y)
+// Returns errOutOfBounds error if the coordinates are outside the display
+// Returns invalidColor error if the stored color is not recognized
+func (d *Display) getPixel(x, y int) (c Color, err error) {
+	// Check if pixel is out of bounds
+	if x < 0 || y < 0 || x >= d.maxX || y >= d.maxY {
+		return Color{}, errOutOfBounds
+	}
+
+	// Get the pixel color - retrieve directly
+	c = d.matrix[x][y]
+
+	// Check if color is valid
+	if colorUnknown(c) {
+		return c, invalidColor
+	}
+
+	return c, nil
+}
+
+// clearScreen resets all pixels in the display to white color
+func (d *Display) clearScreen() {
+	d.PixelWalk(func(x, y int, c Color) Color {
+		return NewColor("white")
+	})
+}
+
+// PixelWalk calls f for every pixel with its current color and stores the color f returns
+// Pixels are visited column by column; the new color is stored through drawPixel
+// Returns the first error reported by drawPixel, leaving the remaining pixels unvisited
+func (d *Display) PixelWalk(f func(x, y int, c Color) Color) (err error) {
+	return d.IterateRegionMut(0, 0, d.maxX, d.maxY, f)
+}
+
+// PixelScan calls f for every pixel with its current color without changing the display
+// Pixels are visited in the same order as PixelWalk
+func (d *Display) PixelScan(f func(x, y int, c Color)) {
+	d.IterateRegion(0, 0, d.maxX, d.maxY, func(x, y int, c Color) error {
+		f(x, y, c)
+		return nil
+	})
+}
+
+// IterateRegion calls f for every pixel in [x0,x1) x [y0,y1) with its current color
+// Pixels are visited column by column, like PixelWalk; an empty region visits nothing
+// Returns errOutOfBounds if the region is not inside the display, or the first error returned
+// by f, leaving the remaining pixels unvisited
+func (d *Display) IterateRegion(x0, y0, x1, y1 int, f func(x, y int, c Color) error) (err error) {
+	if x0 < 0 || y0 < 0 || x1 > d.maxX || y1 > d.maxY {
+		return errOutOfBounds
+	}
+	for x := x0; x < x1; x++ {
+		for y := y0; y < y1; y++ {
+			if err = f(x, y, d.matrix[x][y]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// IterateRegionMut is IterateRegion storing the color f returns for every pixel
+// The new color is stored through drawPixel
+// Returns errOutOfBounds if the region is not inside the display, or the first error reported
+// by drawPixel, leaving the remaining pixels unvisited
+func (d *Display) IterateRegionMut(x0, y0, x1, y1 int, f func(x, y int, c Color) Color) (err error) {
+	return d.IterateRegion(x0, y0, x1, y1, func(x, y int, c Color) error {
+		return d.drawPixel(x, y, f(x, y, c))
+	})
+}
+
+// ScanRow returns a copy of the colors of row y, from x = 0 to maxX-1
+// Returns errOutOfBounds if y is not a row of the display
+func (d *Display) ScanRow(y int) ([]Color, error) {
+	if y < 0 || y >= d.maxY {
+		return nil, errOutOfBounds
+	}
+	row := make([]Color, d.maxX)
+	for x := range row {
+		row[x] = d.matrix[x][y]
+	}
+	return row, nil
+}
+
+// ScanColumn returns a copy of the colors of column x, from y = 0 to maxY-1
+// Returns errOutOfBounds if x is not a column of the display
+func (d *Display) ScanColumn(x int) ([]Color, error) {
+	if x < 0 || x >= d.maxX {
+		return nil, errOutOfBounds
+	}
+	return append([]Color(nil), d.matrix[x]...), nil
+}
+
+// checkLine returns an error if colors cannot replace a row or column of n pixels
+// Returns errDimensionMismatch if there are not n colors, or invalidColor if one is unknown
+func checkLine(colors []Color, n int) error {
+	if len(colors) != n {
+		return errDimensionMismatch
+	}
+	for _, c := range colors {
+		if colorUnknown(c) {
+			return invalidColor
+		}
+	}
+	return nil
+}
+
+// SetRow replaces the colors of row y with colors, one per column
+// The row is left unchanged if an error is returned
+// Returns errOutOfBounds if y is not a row of the display, errDimensionMismatch if there
+// is not one color per column, or invalidColor for an unknown color
+func (d *Display) SetRow(y int, colors []Color) error {
+	if y < 0 || y >= d.maxY {
+		return errOutOfBounds
+	}
+	if err := checkLine(colors, d.maxX); err != nil {
+		return err
+	}
+	for x, c := range colors {
+		d.matrix[x][y] = c
+	}
+	return nil
+}
+
+// SetColumn replaces the colors of column x with colors, one per row
+// The column is left unchanged if an error is returned
+// Returns errOutOfBounds if x is not a column of the display, errDimensionMismatch if there
+// is not one color per row, or invalidColor for an unknown color
+func (d *Display) SetColumn(x int, colors []Color) error {
+	if x < 0 || x >= d.maxX {
+		return errOutOfBounds
+	}
+	if err := checkLine(colors, d.maxY); err != nil {
+		return err
+	}
+	copy(d.matrix[x], colors)
+	return nil
+}
+
+// PixelUpdate is a single pixel change for SetPixelBatch
+type PixelUpdate struct {
+	X, Y int   // Pixel coordinates
+	C    Color // New color
+}
+
+// SetPixelBatch applies every update through drawPixel, so bounds are handled by the display's BoundsMode
+// A failed update does not stop the remaining ones; returns the DrawError of every update that failed
+func (d *Display) SetPixelBatch(updates []PixelUpdate) (errs []error) {
+	for _, u := range updates {
+		if err := d.drawPixel(u.X, u.Y, u.C); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// SetPixelBatchNoCheck stores every update straight into the display without checking
+// bounds or colors, for callers that have already validated them
+// An update outside the display panics; an unknown color is stored as it is
+func (d *Display) SetPixelBatchNoCheck(updates []PixelUpdate) {
+	for _, u := range updates {
+		d.matrix[u.X][u.Y] = u.C
+	}
+}
+
+// DrawWithMode draws the shape on the display in the given mode
+// Returns any error reported by the shape's DrawOn method
+func (d *Display) DrawWithMode(g geometry, mode DrawMode) (err error) {
+	return g.DrawOn(d, mode)
+}
+
+// DrawWithClip draws the shape on the display, clipping it to the display's edges
+// Parts of the shape outside the display are skipped instead of returning errOutOfBounds;
+// rectangles and triangles only fill the part of their area that lies on the display
+// Returns any other error reported by the shape's DrawOn method
+func (d *Display) DrawWithClip(g geometry) (err error) {
+	return g.DrawOn(clipScreen{d}, DrawDefault)
+}
+
+// DrawWithCallback draws the shape on the display, calling before and after around every pixel drawn
+// Either callback may be nil; useful for logging, counting or profiling the pixels a shape draws
+// Returns any error reported by the shape's DrawOn method
+func (d *Display) DrawWithCallback(g geometry, before, after func(x, y int, c Color)) (err error) {
+	return g.DrawOn(callbackScreen{d, before, after}, DrawDefault)
+}
+
+// drawShaded fills the shape on the display with the colors returned by shade
+// The shape's own fill color is only a placeholder so that DrawOn accepts it
+func (d *Display) drawShaded(g geometry, shade func(x, y int) Color) (err error) {
+	return g.DrawOn(shadeScreen{d, shade}, DrawFill)
+}
+
+// DrawCircleWithCallback fills the circle of radius r around (cx,cy), coloring each pixel
+// with colorFn(x, y, cx, cy, r); useful for radial gradients and procedural textures
+// Returns errOutOfBounds if the circle does not fit on the display, or invalidColor
+// (as a DrawError) if colorFn returns an unknown color
+func (d *Display) DrawCircleWithCallback(cx, cy, r int, colorFn func(x, y int, cx, cy, r int) Color) (err error) {
+	return d.drawShaded(Circle{center: Point{cx, cy}, r: r, c: NewColor("black"), Thickness: 1}, func(x, y int) Color {
+		return colorFn(x, y, cx, cy, r)
+	})
+}
+
+// DrawRectangleWithCallback fills the rectangle from ll to ur, coloring each pixel
+// with colorFn(x, y, ll, ur)
+// Returns the same errors as DrawCircleWithCallback
+func (d *Display) DrawRectangleWithCallback(ll, ur Point, colorFn func(x, y int, ll, ur Point) Color) (err error) {
+	return d.drawShaded(Rectangle{ll: ll, ur: ur, c: NewColor("black"), Thickness: 1}, func(x, y int) Color {
+		return colorFn(x, y, ll, ur)
+	})
+}
+
+// DrawTriangleWithCallback fills the triangle with corners pt0, pt1 and pt2, coloring each pixel
+// with colorFn(x, y, pt0, pt1, pt2)
+// Returns the same errors as DrawCircleWithCallback
+func (d *Display) DrawTriangleWithCallback(pt0, pt1, pt2 Point, colorFn func(x, y int, pt0, pt1, pt2 Point) Color) (err error) {
+	return d.drawShaded(Triangle{pt0: pt0, pt1: pt1, pt2: pt2, c: NewColor("black"), Thickness: 1}, func(x, y int) Color {
+		return colorFn(x, y, pt0, pt1, pt2)
+	})
+}
+
+// EraseShape paints over the shape with the display's background color, white
+// Both the fill and the outline of the shape are erased, whatever mode it was drawn in
+// Whatever the shape covered is not restored: shapes drawn beneath it are erased too
+// Returns the same errors as drawing the shape with DrawOn
+func (d *Display) EraseShape(g geometry) (err error) {
+	return g.DrawOn(d.background(), DrawBoth)
+}
+
+// background returns the display wrapped so that everything drawn on it is painted in
+// the background color, white
+func (d *Display) background() screen {
+	return shadeScreen{d, func(x, y int) Color { return NewColor("white") }}
+}
+
+// ClearRegion resets the rectangle with corners (x0,y0) and (x1,y1), both included, to the
+// background color, white
+// Returns errOutOfBounds if a corner is outside the display
+func (d *Display) ClearRegion(x0, y0, x1, y1 int) error {
+	if !(Point{x0, y0}).InBounds(d) || !(Point{x1, y1}).InBounds(d) {
+		return errOutOfBounds
+	}
+	return d.IterateRegionMut(min(x0, x1), min(y0, y1), max(x0, x1)+1, max(y0, y1)+1, func(x, y int, c Color) Color {
+		return NewColor("white")
+	})
+}
+
+// ClearCircle resets the pixels of the filled circle of radius r around (cx,cy) to the background color
+// Returns the same errors as drawing the circle
+func (d *Display) ClearCircle(cx, cy, r int) error {
+	return d.ClearShape(Circle{center: Point{cx, cy}, r: r, c: NewColor("white"), Thickness: 1})
+}
+
+// ClearShape resets the pixels the shape colors when drawn in the default mode to the background color
+// Unlike EraseShape, an outline drawn only in DrawOutline or DrawBoth mode is kept
+// Returns the same errors as drawing the shape with DrawOn
+func (d *Display) ClearShape(g geometry) error {
+	return g.DrawOn(d.background(), DrawDefault)
+}
+
+// DrawShadow draws a copy of the shape moved by (offsetX, offsetY) in shadowColor,
+// then the shape itself on top
+// A shadow that leaves the display returns errOutOfBounds like any other shape; set the display
+// to BoundsClip to let it run off the edge instead
+// Returns invalidColor for an unknown shadow color, or the first error from drawing the shadow or the shape
+func (d *Display) DrawShadow(g geometry, offsetX, offsetY int, shadowColor Color) (err error) {
+	if colorUnknown(shadowColor) {
+		return invalidColor
+	}
+	shadow := shadeScreen{d, func(x, y int) Color { return shadowColor }}
+	if err = g.OffsetBy(offsetX, offsetY).DrawOn(shadow, DrawDefault); err != nil {
+		return err
+	}
+	return g.DrawOn(d, DrawDefault)
+}
+
+// DrawWithOpacity draws the shape over the display at the given opacity in [0,1]
+// The shape is drawn on a blank display of the same size first, then every pixel it covered is
+// blended over the receiver; an opacity of 1 is the same as DrawOn and 0 leaves the display unchanged
+// Returns errInvalidOpacity if opacity is outside [0,1], or any error reported by the shape's DrawOn method
+func (d *Display) DrawWithOpacity(g geometry, opacity float64) (err error) {
+	if !(opacity >= 0 && opacity <= 1) {
+		return errInvalidOpacity
+	}
+	layer := newCoverageDisplay(d.maxX, d.maxY)
+	layer.bounds = d.bounds
+	if err = g.DrawOn(layer, DrawDefault); err != nil {
+		return err
+	}
+	for x := range layer.matrix {
+		for y, c := range layer.matrix[x] {
+			if c == (Color{}) {
+				continue
+			}
+			src, _ := colorToRGB(c)
+			dst, _ := colorToRGB(d.matrix[x][y])
+			d.matrix[x][y] = blendRGB(dst, src, opacity)
+		}
+	}
+	return nil
+}
+
+// screenShot saves the current state of the display to a PPM image file
+// The file format follows the P3 PPM format with RGB values
+// Returns fileError if there was a problem creating or writing to the file
+func (d *Display) screenShot(f string) (err error) {
+	file, err := os.Create(f + ".ppm")
+	if err != nil {
+		return fileError
+	}
+	defer file.Close()
+
+	// Write header: columns (width) first, then rows (height)
+	if _, err = fmt.Fprintf(file, "P3\n%d %d\n255\n", d.maxX, d.maxY); err != nil {
+		return fileError
+	}
+
+	// Write pixel data row by row, top to bottom
+	for y := 0; y < d.maxY; y++ {
+		for x := 0; x < d.maxX; x++ {
+			rgb, _ := colorToRGB(d.matrix[x][y])
+
+			// Write RGB values with space separator, no newline between pixels
+			if x > 0 {
+				if _, err = fmt.Fprint(file, " "); err != nil {
+					return fileError
+				}
+			}
+
+			if _, err = fmt.Fprintf(file, "%d %d %d", rgb.R, rgb.G, rgb.B); err != nil {
+				return fileError
+			}
+		}
+
+		// Only add newline at the end of each row
+		if _, err = fmt.Fprintln(file); err != nil {
+			return fileError
+		}
+	}
+
+	return nil
+}
+
+// min returns the minimum of two integers
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// max returns the maximum of two integers
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
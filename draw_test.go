@@ -0,0 +1,511 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestPixelWalk_Identity checks that a PixelWalk returning every color unchanged
+// leaves the display as it was, and that PixelScan visits every pixel once
+func TestPixelWalk_Identity(t *testing.T) {
+	d := newDisplay(16, 9)
+	if err := d.DrawGradientBackground(NewColor("red"), NewColor("green"), NewColor("blue"), NewColor("white")); err != nil {
+		t.Fatalf("DrawGradientBackground: %v", err)
+	}
+	want := d.Clone()
+	if err := d.PixelWalk(func(x, y int, c Color) Color { return c }); err != nil {
+		t.Fatalf("PixelWalk: %v", err)
+	}
+	if !d.Equal(want) {
+		t.Error("identity PixelWalk changed the display")
+	}
+
+	visits := map[Point]int{}
+	d.PixelScan(func(x, y int, c Color) { visits[Point{x, y}]++ })
+	if len(visits) != 16*9 {
+		t.Errorf("PixelScan visited %d pixels, want %d", len(visits), 16*9)
+	}
+}
+
+// TestPixelWalk_Transform checks that the returned colors are stored
+func TestPixelWalk_Transform(t *testing.T) {
+	d := newDisplay(6, 4)
+	if err := d.PixelWalk(func(x, y int, c Color) Color {
+		if x == y {
+			return NewColor("black")
+		}
+		return c
+	}); err != nil {
+		t.Fatalf("PixelWalk: %v", err)
+	}
+	if got := d.Count(NewColor("black")); got != 4 {
+		t.Errorf("got %d black pixels, want 4", got)
+	}
+	if err := d.PixelWalk(func(x, y int, c Color) Color { return NewColor("mauve") }); !errors.Is(err, invalidColor) {
+		t.Errorf("unknown color: got %v, want invalidColor", err)
+	}
+}
+
+// TestClone_Independent checks that changing a clone does not change the source and vice versa
+func TestClone_Independent(t *testing.T) {
+	src := newDisplay(5, 4)
+	clone := src.Clone()
+	if !clone.Equal(src) {
+		t.Fatal("the clone differs from its source")
+	}
+	clone.matrix[2][3] = NewColor("red")
+	if err := src.ComparePixel(2, 3, NewColor("white")); err != nil {
+		t.Errorf("source after changing the clone: %v", err)
+	}
+	src.matrix[0][0] = NewColor("blue")
+	if err := clone.ComparePixel(0, 0, NewColor("white")); err != nil {
+		t.Errorf("clone after changing the source: %v", err)
+	}
+	if clone.Equal(src) {
+		t.Error("Equal is true for different displays")
+	}
+	if src.Equal(newDisplay(4, 5)) || src.Equal(nil) {
+		t.Error("Equal is true for a display of another size or nil")
+	}
+}
+
+// countingScreen is a clipScreen that counts the pixels shapes try to draw on it
+type countingScreen struct {
+	clipScreen
+	drawn *int // Number of drawPixel calls
+}
+
+// drawPixel counts the pixel and passes it on to the clipScreen
+func (cs countingScreen) drawPixel(x, y int, c Color) error {
+	*cs.drawn++
+	return cs.clipScreen.drawPixel(x, y, c)
+}
+
+// TestDrawWithClip_Rectangle checks that a rectangle from (-5,-5) to (10,10) fills the 10x10
+// top-left corner and that only those 100 pixels are visited
+func TestDrawWithClip_Rectangle(t *testing.T) {
+	red := NewColor("red")
+	r := NewRectangle(Point{-5, -5}, Point{10, 10}, red)
+	d := newDisplay(20, 20)
+	if err := d.DrawWithClip(r); err != nil {
+		t.Fatalf("DrawWithClip: %v", err)
+	}
+	for _, err := range d.AssertRegion(0, 0, 9, 9, red) {
+		t.Error(err)
+	}
+	if got := d.Count(red); got != 100 {
+		t.Errorf("got %d red pixels, want 100", got)
+	}
+
+	var drawn int
+	if err := r.DrawOn(countingScreen{clipScreen{newDisplay(20, 20)}, &drawn}, DrawFill); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+	if drawn != 100 {
+		t.Errorf("visited %d pixels, want 100", drawn)
+	}
+}
+
+// TestDrawWithClip_Triangle checks that a triangle much larger than the display only visits its on-screen scanlines
+func TestDrawWithClip_Triangle(t *testing.T) {
+	green := NewColor("green")
+	tri := NewTriangle(Point{-1000, -1000}, Point{1000, -1000}, Point{0, 1000}, green)
+	d := newDisplay(10, 10)
+	var drawn int
+	if err := tri.DrawOn(countingScreen{clipScreen{d}, &drawn}, DrawFill); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+	if got := d.Count(green); got != 100 || drawn != 100 {
+		t.Errorf("got %d green pixels after visiting %d, want 100 of 100", got, drawn)
+	}
+}
+
+// TestSetBoundsMode_StraddlingRectangle checks each bounds mode with a rectangle crossing the right edge
+func TestSetBoundsMode_StraddlingRectangle(t *testing.T) {
+	red := NewColor("red")
+	r := NewRectangle(Point{7, 2}, Point{13, 5}, red)
+
+	d := newDisplay(10, 10)
+	if err := r.DrawOn(d, DrawFill); !errors.Is(err, errOutOfBounds) {
+		t.Errorf("BoundsError: got %v, want errOutOfBounds", err)
+	}
+
+	d.SetBoundsMode(BoundsClip)
+	if err := r.DrawOn(d, DrawFill); err != nil {
+		t.Fatalf("BoundsClip: %v", err)
+	}
+	for _, err := range d.AssertRegion(7, 2, 9, 4, red) {
+		t.Errorf("BoundsClip: %v", err)
+	}
+	if got := d.Count(red); got != 9 {
+		t.Errorf("BoundsClip: got %d red pixels, want 9", got)
+	}
+
+	d = newDisplay(10, 10)
+	d.SetBoundsMode(BoundsWrap)
+	if err := r.DrawOn(d, DrawFill); err != nil {
+		t.Fatalf("BoundsWrap: %v", err)
+	}
+	for _, err := range append(d.AssertRegion(7, 2, 9, 4, red), d.AssertRegion(0, 2, 2, 4, red)...) {
+		t.Errorf("BoundsWrap: %v", err)
+	}
+	if got := d.Count(red); got != 18 {
+		t.Errorf("BoundsWrap: got %d red pixels, want 18", got)
+	}
+}
+
+// TestDrawWithCallback_CountsPixels checks that both callbacks fire once per pixel of a known rectangle
+// and that a nil callback is skipped
+func TestDrawWithCallback_CountsPixels(t *testing.T) {
+	d := newDisplay(20, 20)
+	var before, after int
+	r := NewRectangle(Point{2, 3}, Point{9, 7}, NewColor("red"))
+	err := d.DrawWithCallback(r, func(x, y int, c Color) { before++ }, func(x, y int, c Color) { after++ })
+	if err != nil {
+		t.Fatalf("DrawWithCallback: %v", err)
+	}
+	if before != 7*4 || after != 7*4 {
+		t.Errorf("got %d before and %d after calls, want %d", before, after, 7*4)
+	}
+	if err := d.DrawWithCallback(r, nil, nil); err != nil {
+		t.Errorf("nil callbacks: %v", err)
+	}
+}
+
+// TestDrawError_Coordinates checks that errors.As recovers the pixel of an out of bounds drawPixel
+// and that errors.Is still matches errOutOfBounds
+func TestDrawError_Coordinates(t *testing.T) {
+	d := newDisplay(10, 10)
+	err := d.drawPixel(12, 3, NewColor("red"))
+	var de *DrawError
+	if !errors.As(err, &de) {
+		t.Fatalf("got %v, want a *DrawError", err)
+	}
+	if de.X != 12 || de.Y != 3 {
+		t.Errorf("got pixel (%d,%d), want (12,3)", de.X, de.Y)
+	}
+	if !errors.Is(err, errOutOfBounds) {
+		t.Errorf("%v does not match errOutOfBounds", err)
+	}
+	if err.Error() != "drawPixel(12,3): "+errOutOfBounds.Error() {
+		t.Errorf("got message %q", err.Error())
+	}
+}
+
+// TestDrawCircleWithCallback_RadialGradient checks a radial gradient from black at the center to
+// white at the edge, and that the rectangle and triangle variants call colorFn for every pixel
+func TestDrawCircleWithCallback_RadialGradient(t *testing.T) {
+	d := newDisplay(41, 41)
+	radial := func(x, y int, cx, cy, r int) Color {
+		v := int(255 * (Point{x, y}).Distance(Point{cx, cy}) / float64(r))
+		return NewColorRGB(v, v, v)
+	}
+	if err := d.DrawCircleWithCallback(20, 20, 10, radial); err != nil {
+		t.Fatalf("DrawCircleWithCallback: %v", err)
+	}
+	for p, want := range map[Point]Color{{20, 20}: NewColor("black"), {25, 20}: NewColorRGB(127, 127, 127), {30, 20}: NewColor("white")} {
+		if err := d.ComparePixel(p.x, p.y, want); err != nil {
+			t.Error(err)
+		}
+	}
+
+	calls := 0
+	if err := d.DrawRectangleWithCallback(Point{0, 0}, Point{4, 3}, func(x, y int, ll, ur Point) Color {
+		calls++
+		return NewColor("red")
+	}); err != nil || calls != 12 {
+		t.Errorf("DrawRectangleWithCallback: %d calls, %v, want 12 calls", calls, err)
+	}
+	if err := d.DrawTriangleWithCallback(Point{0, 0}, Point{8, 0}, Point{4, 8}, func(x, y int, p0, p1, p2 Point) Color {
+		return NewColor("mauve")
+	}); !errors.Is(err, invalidColor) {
+		t.Errorf("unknown color from colorFn: got %v, want invalidColor", err)
+	}
+	if err := d.DrawCircleWithCallback(38, 20, 10, radial); err != errOutOfBounds {
+		t.Errorf("circle off the display: got %v, want errOutOfBounds", err)
+	}
+}
+
+// TestEraseShape_Rectangle checks that erasing a drawn rectangle leaves its whole region white,
+// including the stroke, and leaves the rest of the display alone
+func TestEraseShape_Rectangle(t *testing.T) {
+	red, blue := NewColor("red"), NewColor("blue")
+	d := newDisplay(20, 20)
+	if err := NewRectangle(Point{0, 0}, Point{19, 3}, blue).DrawOn(d, DrawFill); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+	r := NewRectangle(Point{4, 5}, Point{12, 10}, red).WithStroke(blue)
+	if err := r.DrawOn(d, DrawBoth); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+	if err := d.EraseShape(r); err != nil {
+		t.Fatalf("EraseShape: %v", err)
+	}
+	for _, err := range d.AssertRegion(4, 5, 11, 9, NewColor("white")) {
+		t.Error(err)
+	}
+	if got := d.Count(blue); got != 19*3 {
+		t.Errorf("got %d blue pixels, want the %d of the other rectangle", got, 19*3)
+	}
+}
+
+// TestDrawWithOpacity_Levels checks that opacity 1 draws like DrawOn, opacity 0 leaves the display
+// unchanged and opacity 0.5 mixes the shape evenly with what is underneath
+func TestDrawWithOpacity_Levels(t *testing.T) {
+	red, blue := NewColor("red"), NewColor("blue")
+	base := newDisplay(20, 20)
+	if err := NewRectangle(Point{0, 0}, Point{10, 19}, blue).DrawOn(base, DrawFill); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+	r := NewRectangle(Point{5, 5}, Point{15, 15}, red)
+
+	want := base.Clone()
+	if err := r.DrawOn(want, DrawDefault); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+	opaque := base.Clone()
+	if err := opaque.DrawWithOpacity(r, 1); err != nil {
+		t.Fatalf("DrawWithOpacity(1): %v", err)
+	}
+	if !opaque.Equal(want) {
+		t.Error("opacity 1 does not match DrawOn")
+	}
+
+	none := base.Clone()
+	if err := none.DrawWithOpacity(r, 0); err != nil {
+		t.Fatalf("DrawWithOpacity(0): %v", err)
+	}
+	if !none.Equal(base) {
+		t.Error("opacity 0 changed the display")
+	}
+
+	half := base.Clone()
+	if err := half.DrawWithOpacity(r, 0.5); err != nil {
+		t.Fatalf("DrawWithOpacity(0.5): %v", err)
+	}
+	for _, p := range []struct {
+		x, y int
+		want RGB
+	}{{7, 7, RGB{128, 0, 128}}, {12, 7, RGB{255, 128, 128}}, {2, 2, RGB{0, 0, 255}}} {
+		got := pixelRGB(half, p.x, p.y)
+		if abs(got.R-p.want.R) > 1 || abs(got.G-p.want.G) > 1 || abs(got.B-p.want.B) > 1 {
+			t.Errorf("pixel (%d,%d) is %v, want %v within 1", p.x, p.y, got, p.want)
+		}
+	}
+
+	for _, opacity := range []float64{-0.1, 1.1} {
+		if err := half.DrawWithOpacity(r, opacity); err != errInvalidOpacity {
+			t.Errorf("opacity %v: got %v, want errInvalidOpacity", opacity, err)
+		}
+	}
+}
+
+// TestDrawShadow_Rectangle checks that the shadow shows below and right of the rectangle while the
+// rectangle keeps its own color, and that a shadow off the display is an error unless it is clipped
+func TestDrawShadow_Rectangle(t *testing.T) {
+	red, black := NewColor("red"), NewColor("black")
+	d := newDisplay(20, 20)
+	r := NewRectangle(Point{4, 4}, Point{10, 10}, red)
+	if err := d.DrawShadow(r, 3, 3, black); err != nil {
+		t.Fatalf("DrawShadow: %v", err)
+	}
+	if err := d.ComparePixel(r.ll.x, r.ll.y, red); err != nil {
+		t.Error(err)
+	}
+	for _, err := range d.AssertRegion(10, 7, 12, 12, black) {
+		t.Error(err)
+	}
+	if err := d.ComparePixel(r.ll.x+3, r.ll.y+3, red); err != nil {
+		t.Errorf("shadow covered the rectangle: %v", err)
+	}
+	if got := d.Count(black); got != 6*6-3*3 {
+		t.Errorf("got %d shadow pixels, want %d", got, 6*6-3*3)
+	}
+
+	if err := d.DrawShadow(r, 12, 0, black); !errors.Is(err, errOutOfBounds) {
+		t.Errorf("shadow off the display: got %v, want errOutOfBounds", err)
+	}
+	d.SetBoundsMode(BoundsClip)
+	if err := d.DrawShadow(r, 12, 0, black); err != nil {
+		t.Errorf("clipped shadow: %v", err)
+	}
+	if err := d.DrawShadow(r, 3, 3, NewColor("mauve")); err != invalidColor {
+		t.Errorf("unknown shadow color: got %v, want invalidColor", err)
+	}
+}
+
+// TestPoint_InBoundsClampLerp checks InBounds at the display edges, Clamp on each side of a
+// rectangle and Lerp at both ends and halfway
+func TestPoint_InBoundsClampLerp(t *testing.T) {
+	d := newDisplay(10, 5)
+	for _, c := range []struct {
+		p    Point
+		want bool
+	}{{Point{0, 0}, true}, {Point{9, 4}, true}, {Point{10, 4}, false}, {Point{9, 5}, false}, {Point{-1, 0}, false}} {
+		if got := c.p.InBounds(d); got != c.want {
+			t.Errorf("%v.InBounds() = %v, want %v", c.p, got, c.want)
+		}
+	}
+
+	for _, c := range []struct{ p, want Point }{
+		{Point{5, 5}, Point{5, 5}}, {Point{-3, 5}, Point{2, 5}}, {Point{20, 20}, Point{8, 8}}, {Point{4, 0}, Point{4, 2}},
+	} {
+		if got := c.p.Clamp(2, 2, 8, 8); got != c.want {
+			t.Errorf("%v.Clamp(2, 2, 8, 8) = %v, want %v", c.p, got, c.want)
+		}
+	}
+
+	a, b := Point{0, 10}, Point{10, 0}
+	for _, c := range []struct {
+		t    float64
+		want Point
+	}{{0, a}, {1, b}, {0.5, Point{5, 5}}, {0.26, Point{3, 7}}} {
+		if got := a.Lerp(b, c.t); got != c.want {
+			t.Errorf("Lerp(%v) = %v, want %v", c.t, got, c.want)
+		}
+	}
+}
+
+// TestIterateRegion_HalfOpen checks that IterateRegion visits exactly [x0,x1) x [y0,y1), stops at
+// the first error, and that IterateRegionMut writes the returned colors back
+func TestIterateRegion_HalfOpen(t *testing.T) {
+	red := NewColor("red")
+	d := newDisplay(10, 8)
+	visited := 0
+	if err := d.IterateRegion(2, 3, 6, 5, func(x, y int, c Color) error {
+		if x < 2 || x >= 6 || y < 3 || y >= 5 {
+			t.Errorf("visited (%d,%d) outside the region", x, y)
+		}
+		visited++
+		return nil
+	}); err != nil {
+		t.Fatalf("IterateRegion: %v", err)
+	}
+	if visited != 4*2 {
+		t.Errorf("visited %d pixels, want 8", visited)
+	}
+
+	stop := errors.New("stop")
+	visited = 0
+	if err := d.IterateRegion(0, 0, 10, 8, func(x, y int, c Color) error {
+		visited++
+		if visited == 3 {
+			return stop
+		}
+		return nil
+	}); err != stop || visited != 3 {
+		t.Errorf("got %v after %d pixels, want stop after 3", err, visited)
+	}
+
+	if err := d.IterateRegionMut(2, 3, 6, 5, func(x, y int, c Color) Color { return red }); err != nil {
+		t.Fatalf("IterateRegionMut: %v", err)
+	}
+	for _, err := range d.AssertRegion(2, 3, 5, 4, red) {
+		t.Error(err)
+	}
+	if got := d.Count(red); got != 8 {
+		t.Errorf("got %d red pixels, want 8", got)
+	}
+	if err := d.IterateRegion(0, 0, 11, 8, func(x, y int, c Color) error { return nil }); err != errOutOfBounds {
+		t.Errorf("region past the edge: got %v, want errOutOfBounds", err)
+	}
+}
+
+// TestScanRow_SetColumn checks that rows and columns read back what was set, that the scans are
+// copies, and the index, length and color checks
+func TestScanRow_SetColumn(t *testing.T) {
+	red, blue := NewColor("red"), NewColor("blue")
+	d := newDisplay(4, 3)
+	if err := d.SetRow(1, []Color{red, blue, red, blue}); err != nil {
+		t.Fatalf("SetRow: %v", err)
+	}
+	if err := d.SetColumn(3, []Color{blue, blue, blue}); err != nil {
+		t.Fatalf("SetColumn: %v", err)
+	}
+
+	row, err := d.ScanRow(1)
+	if err != nil {
+		t.Fatalf("ScanRow: %v", err)
+	}
+	if fmt.Sprint(row) != fmt.Sprint([]Color{red, blue, red, blue}) {
+		t.Errorf("row 1 is %v", row)
+	}
+	col, err := d.ScanColumn(3)
+	if err != nil {
+		t.Fatalf("ScanColumn: %v", err)
+	}
+	if len(col) != 3 || col[0] != blue || col[2] != blue {
+		t.Errorf("column 3 is %v", col)
+	}
+	col[0] = red
+	if err := d.ComparePixel(3, 0, blue); err != nil {
+		t.Errorf("changing the scanned column changed the display: %v", err)
+	}
+
+	if _, err := d.ScanRow(3); err != errOutOfBounds {
+		t.Errorf("ScanRow(3): got %v, want errOutOfBounds", err)
+	}
+	if _, err := d.ScanColumn(-1); err != errOutOfBounds {
+		t.Errorf("ScanColumn(-1): got %v, want errOutOfBounds", err)
+	}
+	if err := d.SetRow(0, []Color{red}); err != errDimensionMismatch {
+		t.Errorf("short row: got %v, want errDimensionMismatch", err)
+	}
+	if err := d.SetColumn(0, []Color{red, NewColor("mauve"), red}); err != invalidColor {
+		t.Errorf("unknown color: got %v, want invalidColor", err)
+	}
+	if err := d.ComparePixel(0, 0, NewColor("white")); err != nil {
+		t.Errorf("a rejected column changed the display: %v", err)
+	}
+}
+
+// TestClearRegion_CircleAndShape checks that ClearRegion, ClearCircle and ClearShape reset exactly
+// the pixels they cover to white, and that a corner off the display is rejected
+func TestClearRegion_CircleAndShape(t *testing.T) {
+	red, white := NewColor("red"), NewColor("white")
+	fullRed := func() *Display {
+		d := newDisplay(30, 30)
+		d.PixelWalk(func(x, y int, c Color) Color { return red })
+		return d
+	}
+
+	d := fullRed()
+	if err := d.ClearRegion(2, 3, 6, 8); err != nil {
+		t.Fatalf("ClearRegion: %v", err)
+	}
+	for _, err := range d.AssertRegion(2, 3, 6, 8, white) {
+		t.Error(err)
+	}
+	if got := d.Count(white); got != 5*6 {
+		t.Errorf("ClearRegion cleared %d pixels, want 30", got)
+	}
+	if err := d.ClearRegion(2, 3, 30, 8); err != errOutOfBounds {
+		t.Errorf("corner off the display: got %v, want errOutOfBounds", err)
+	}
+
+	d, circle := fullRed(), newDisplay(30, 30)
+	if err := d.ClearCircle(15, 15, 8); err != nil {
+		t.Fatalf("ClearCircle: %v", err)
+	}
+	if err := NewCircle(Point{15, 15}, 8, red).DrawOn(circle, DrawDefault); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+	d.PixelScan(func(x, y int, c Color) {
+		if inside := circle.matrix[x][y] == red; inside != (c == white) {
+			t.Errorf("pixel (%d,%d) is %v, inside the circle: %v", x, y, c, inside)
+		}
+	})
+
+	d = fullRed()
+	tr := NewTriangle(Point{2, 2}, Point{20, 2}, Point{2, 20}, NewColor("blue"))
+	if err := d.ClearShape(tr); err != nil {
+		t.Fatalf("ClearShape: %v", err)
+	}
+	if err := tr.DrawOn(circle, DrawDefault); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+	if got, want := d.Count(white), circle.Count(NewColor("blue")); got != want {
+		t.Errorf("ClearShape cleared %d pixels, want the %d of the triangle", got, want)
+	}
+}
@@ -0,0 +1,385 @@
+package main
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+)
+
+// lerp linearly interpolates between a and b by the fraction t
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
+
+// DrawGradientBackground fills the whole display with a bilinear gradient
+// between the four corner colors
+// Each pixel is stored as an inline RGB Color
+// Returns invalidColor if any of the corner colors is unknown
+func (d *Display) DrawGradientBackground(topLeft, topRight, bottomLeft, bottomRight Color) (err error) {
+	corners := [4]RGB{}
+	for i, c := range []Color{topLeft, topRight, bottomLeft, bottomRight} {
+		if corners[i], err = colorToRGB(c); err != nil {
+			return invalidColor
+		}
+	}
+	tl, tr, bl, br := corners[0], corners[1], corners[2], corners[3]
+
+	// channel blends one component of the four corners at (t, s)
+	channel := func(a, b, c, e int, t, s float64) int {
+		top := lerp(float64(a), float64(b), t)
+		bottom := lerp(float64(c), float64(e), t)
+		return int(math.Round(lerp(top, bottom, s)))
+	}
+
+	for x := 0; x < d.maxX; x++ {
+		t := 0.0
+		if d.maxX > 1 {
+			t = float64(x) / float64(d.maxX-1)
+		}
+		for y := 0; y < d.maxY; y++ {
+			s := 0.0
+			if d.maxY > 1 {
+				s = float64(y) / float64(d.maxY-1)
+			}
+			d.matrix[x][y] = NewColorRGB(
+				channel(tl.R, tr.R, bl.R, br.R, t, s),
+				channel(tl.G, tr.G, bl.G, br.G, t, s),
+				channel(tl.B, tr.B, bl.B, br.B, t, s),
+			)
+		}
+	}
+	return nil
+}
+
+// drawNoise sets each pixel to a random color from palette with probability density
+// The same seed always produces the same pattern
+func (d *Display) drawNoise(density float64, seed int64, palette []Color) error {
+	if density < 0 || density > 1 || math.IsNaN(density) {
+		return errInvalidDensity
+	}
+	rng := rand.New(rand.NewSource(seed))
+	for x := 0; x < d.maxX; x++ {
+		for y := 0; y < d.maxY; y++ {
+			if rng.Float64() < density {
+				d.matrix[x][y] = palette[rng.Intn(len(palette))]
+			}
+		}
+	}
+	return nil
+}
+
+// DrawNoise sets each pixel to a random ColorMap color with probability density
+// Uses a generator seeded with seed, so the same seed always produces the same pattern
+// Returns errInvalidDensity if density is outside [0,1]
+func (d *Display) DrawNoise(density float64, seed int64) error {
+	var palette []Color
+	for _, name := range ColorNames() {
+		palette = append(palette, NewColor(name))
+	}
+	return d.drawNoise(density, seed, palette)
+}
+
+// DrawSaltPepper is the DrawNoise variant that only uses black and white pixels
+// Returns errInvalidDensity if density is outside [0,1]
+func (d *Display) DrawSaltPepper(density float64, seed int64) error {
+	return d.drawNoise(density, seed, []Color{NewColor("black"), NewColor("white")})
+}
+
+// DrawMandelbrot renders the Mandelbrot set centered on centerR+centerI*i
+// Pixel (x,y) maps to c = (centerR + (x-maxX/2)/zoom) + (centerI + (y-maxY/2)/zoom)i and
+// z = z*z + c is iterated up to maxIter times
+// Escaping pixels are colored by their escape count modulo the ColorMap colors (except black),
+// pixels that never escape are black
+// Returns errInvalidIterations for maxIter < 1 or errInvalidZoom for zoom <= 0
+func (d *Display) DrawMandelbrot(centerR, centerI, zoom float64, maxIter int) error {
+	if maxIter < 1 {
+		return errInvalidIterations
+	}
+	if zoom <= 0 {
+		return errInvalidZoom
+	}
+
+	var palette []Color
+	for _, name := range ColorNames() {
+		if name != "black" {
+			palette = append(palette, NewColor(name))
+		}
+	}
+
+	for x := 0; x < d.maxX; x++ {
+		for y := 0; y < d.maxY; y++ {
+			c := complex(centerR+float64(x-d.maxX/2)/zoom, centerI+float64(y-d.maxY/2)/zoom)
+			z := complex(0, 0)
+			color := NewColor("black")
+			for i := 0; i < maxIter; i++ {
+				z = z*z + c
+				if cmplx.Abs(z) > 2 {
+					color = palette[i%len(palette)]
+					break
+				}
+			}
+			d.matrix[x][y] = color
+		}
+	}
+	return nil
+}
+
+// plotColumns draws one pixel per column x at the row given by rowAt(x), rounded to the nearest pixel
+// Rows outside the display (or not a number) are skipped
+// Returns invalidColor if the color is not recognized
+func (d *Display) plotColumns(rowAt func(x int) float64, c Color) error {
+	if colorUnknown(c) {
+		return invalidColor
+	}
+	for x := 0; x < d.maxX; x++ {
+		y := rowAt(x)
+		if math.IsNaN(y) || y < -0.5 || y >= float64(d.maxY)-0.5 {
+			continue
+		}
+		d.matrix[x][int(math.Round(y))] = c
+	}
+	return nil
+}
+
+// DrawSineWave plots y = yOffset + amplitude*sin(2π*frequency*x + phase) for every column x
+// Points that fall outside the display are skipped
+// Returns invalidColor if the color is not recognized
+func (d *Display) DrawSineWave(amplitude, frequency, phase, yOffset float64, c Color) error {
+	return d.plotColumns(func(x int) float64 {
+		return yOffset + amplitude*math.Sin(2*math.Pi*frequency*float64(x)+phase)
+	}, c)
+}
+
+// DrawCosinePlot plots y = yOffset + amplitude*cos(2π*frequency*x + phase) for every column x
+// It is DrawSineWave with the phase shifted by π/2
+func (d *Display) DrawCosinePlot(amplitude, frequency, phase, yOffset float64, c Color) error {
+	return d.DrawSineWave(amplitude, frequency, phase+math.Pi/2, yOffset, c)
+}
+
+// DrawFunctionPlot plots the row y = f(x) for every column, where the columns are mapped
+// linearly onto x values from xMin (first column) to xMax (last column)
+// Points that fall outside the display are skipped
+// Returns invalidColor if the color is not recognized
+func (d *Display) DrawFunctionPlot(f func(x float64) float64, xMin, xMax float64, c Color) error {
+	return d.plotColumns(func(x int) float64 {
+		return f(d.columnValue(x, xMin, xMax))
+	}, c)
+}
+
+// columnValue maps column x linearly onto the x value range from xMin (first column) to xMax (last column)
+func (d *Display) columnValue(x int, xMin, xMax float64) float64 {
+	t := 0.0
+	if d.maxX > 1 {
+		t = float64(x) / float64(d.maxX-1)
+	}
+	return lerp(xMin, xMax, t)
+}
+
+// DrawFilledCurve fills, in every column, the rows between y = f(x) and yBaseline (both included,
+// rounded to the nearest pixel), mapping the columns onto x values like DrawFunctionPlot
+// Rows outside the display are skipped, as are columns where f is not a number
+// Returns invalidColor if the color is not recognized
+func (d *Display) DrawFilledCurve(f func(x float64) float64, xMin, xMax, yBaseline float64, c Color) error {
+	if colorUnknown(c) {
+		return invalidColor
+	}
+	base := math.Round(yBaseline)
+	for x := 0; x < d.maxX; x++ {
+		y := math.Round(f(d.columnValue(x, xMin, xMax)))
+		if math.IsNaN(y) {
+			continue
+		}
+		lo := math.Max(math.Min(y, base), 0)
+		hi := math.Min(math.Max(y, base), float64(d.maxY-1))
+		for row := lo; row <= hi; row++ {
+			d.matrix[x][int(row)] = c
+		}
+	}
+	return nil
+}
+
+// spiralSteps is the number of angle increments used to draw a spiral
+const spiralSteps = 1000
+
+// DrawSpiral draws a spiral around (cx,cy) making the given number of turns
+// The radius grows linearly with the angle from startR to endR, and the angle is stepped
+// in spiralSteps increments; points outside the display are skipped
+// Returns errInvalidTurns for turns < 1 or invalidColor for an unknown color
+func (d *Display) DrawSpiral(cx, cy, turns int, startR, endR float64, c Color) error {
+	if turns < 1 {
+		return errInvalidTurns
+	}
+	if colorUnknown(c) {
+		return invalidColor
+	}
+
+	total := 2 * math.Pi * float64(turns)
+	for i := 0; i <= spiralSteps; i++ {
+		theta := total * float64(i) / spiralSteps
+		r := startR + (endR-startR)*theta/total
+		x := cx + int(math.Round(r*math.Cos(theta)))
+		y := cy + int(math.Round(r*math.Sin(theta)))
+		if x >= 0 && y >= 0 && x < d.maxX && y < d.maxY {
+			d.matrix[x][y] = c
+		}
+	}
+	return nil
+}
+
+// DrawVoronoi colors every pixel with the color of its nearest seed (Euclidean distance),
+// seeds[i] having colors[i]; on a tie the earlier seed wins
+// Uses the straightforward O(pixels x seeds) search, which could be replaced by jump flooding
+// if large seed counts become common
+// Returns errArgumentMismatch if there is not one color per seed, errEmptyPalette if there
+// are no seeds, or invalidColor for an unknown color
+func (d *Display) DrawVoronoi(seeds []Point, colors []Color) error {
+	if len(seeds) != len(colors) {
+		return errArgumentMismatch
+	}
+	if len(seeds) == 0 {
+		return errEmptyPalette
+	}
+	for _, c := range colors {
+		if colorUnknown(c) {
+			return invalidColor
+		}
+	}
+	for x := 0; x < d.maxX; x++ {
+		for y := 0; y < d.maxY; y++ {
+			// Squared distances are enough to compare
+			best, bestDist := 0, -1
+			for i, s := range seeds {
+				dx, dy := x-s.x, y-s.y
+				if dist := dx*dx + dy*dy; bestDist < 0 || dist < bestDist {
+					best, bestDist = i, dist
+				}
+			}
+			d.matrix[x][y] = colors[best]
+		}
+	}
+	return nil
+}
+
+// splineGridSteps is the number of cells DrawSplineGrid splits the patch into along each direction
+const splineGridSteps = 32
+
+// bernstein returns the Bernstein basis polynomials of degree n at t
+func bernstein(n int, t float64) []float64 {
+	b := make([]float64, n+1)
+	coef := 1.0
+	for i := 0; i <= n; i++ {
+		b[i] = coef * math.Pow(t, float64(i)) * math.Pow(1-t, float64(n-i))
+		coef = coef * float64(n-i) / float64(i+1)
+	}
+	return b
+}
+
+// DrawSplineGrid fills the Bézier patch defined by the (nx+1) x (ny+1) control points cp,
+// cp[i][j] being the control point in column i and row j
+// The patch is sampled on a splineGridSteps x splineGridSteps grid and every cell is filled as a
+// quadrilateral; a flat, evenly spaced grid of control points fills the rectangle it spans
+// Returns errInvalidShape for nx or ny < 1, errArgumentMismatch if cp does not have nx+1 columns of
+// ny+1 points, errOutOfBounds if a control point is outside the display (the patch never leaves the
+// hull of its control points), or invalidColor for an unknown color
+func (d *Display) DrawSplineGrid(nx, ny int, cp [][]Point, c Color) error {
+	if nx < 1 || ny < 1 {
+		return errInvalidShape
+	}
+	if len(cp) != nx+1 {
+		return errArgumentMismatch
+	}
+	var all []Point
+	for _, col := range cp {
+		if len(col) != ny+1 {
+			return errArgumentMismatch
+		}
+		all = append(all, col...)
+	}
+	if anyOutOfBounds(d, all...) {
+		return errOutOfBounds
+	}
+	if colorUnknown(c) {
+		return invalidColor
+	}
+
+	// at evaluates the patch at (u,v), rounded to the nearest pixel
+	at := func(u, v float64) Point {
+		bu, bv := bernstein(nx, u), bernstein(ny, v)
+		var x, y float64
+		for i := range cp {
+			for j, p := range cp[i] {
+				w := bu[i] * bv[j]
+				x += w * float64(p.x)
+				y += w * float64(p.y)
+			}
+		}
+		return Point{int(math.Round(x)), int(math.Round(y))}
+	}
+
+	var grid [splineGridSteps + 1][splineGridSteps + 1]Point
+	for i := range grid {
+		for j := range grid[i] {
+			grid[i][j] = at(float64(i)/splineGridSteps, float64(j)/splineGridSteps)
+		}
+	}
+	for i := 0; i < splineGridSteps; i++ {
+		for j := 0; j < splineGridSteps; j++ {
+			cell := []Point{grid[i][j], grid[i+1][j], grid[i+1][j+1], grid[i][j+1]}
+			if err := fillPolygon(d, cell, c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// floodRegion returns a mask of the pixels reachable from the seed through 4-connected
+// neighbors of the seed's color, without changing the display
+// The seed must be inside the display
+func (d *Display) floodRegion(seed Point) [][]bool {
+	region := make([][]bool, d.maxX)
+	for x := range region {
+		region[x] = make([]bool, d.maxY)
+	}
+	target := d.matrix[seed.x][seed.y]
+	region[seed.x][seed.y] = true
+	stack := []Point{seed}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, n := range []Point{{p.x - 1, p.y}, {p.x + 1, p.y}, {p.x, p.y - 1}, {p.x, p.y + 1}} {
+			if n.InBounds(d) && !region[n.x][n.y] && sameColor(d.matrix[n.x][n.y], target) {
+				region[n.x][n.y] = true
+				stack = append(stack, n)
+			}
+		}
+	}
+	return region
+}
+
+// DrawFloodContour outlines the region a flood fill from (seedX,seedY) would cover: the pixels
+// reachable through 4-connected neighbors of the seed's color
+// Only the region's boundary pixels, those with a 4-neighbor outside the region or the display,
+// are drawn in outlineColor; the rest of the region is left unchanged
+// Returns errOutOfBounds if the seed is outside the display, or invalidColor for an unknown color
+func (d *Display) DrawFloodContour(seedX, seedY int, outlineColor Color) error {
+	seed := Point{seedX, seedY}
+	if !seed.InBounds(d) {
+		return errOutOfBounds
+	}
+	if colorUnknown(outlineColor) {
+		return invalidColor
+	}
+	region := d.floodRegion(seed)
+	in := func(x, y int) bool {
+		return (Point{x, y}).InBounds(d) && region[x][y]
+	}
+	for x := range region {
+		for y := range region[x] {
+			if region[x][y] && !(in(x-1, y) && in(x+1, y) && in(x, y-1) && in(x, y+1)) {
+				d.matrix[x][y] = outlineColor
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,318 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// TestDrawGradientBackground_Corners checks that the top-left pixel is the top-left color
+// exactly and the center pixel is the average of the four corners
+func TestDrawGradientBackground_Corners(t *testing.T) {
+	d := newDisplay(11, 11)
+	if err := d.DrawGradientBackground(NewColor("red"), NewColor("green"), NewColor("blue"), NewColor("white")); err != nil {
+		t.Fatalf("DrawGradientBackground: %v", err)
+	}
+
+	if err := d.ComparePixel(0, 0, NewColor("red")); err != nil {
+		t.Error(err)
+	}
+	got, _ := colorToRGB(d.matrix[5][5])
+	want := RGB{(255 + 0 + 0 + 255) / 4, (0 + 255 + 0 + 255) / 4, (0 + 0 + 255 + 255) / 4}
+	if abs(got.R-want.R) > 1 || abs(got.G-want.G) > 1 || abs(got.B-want.B) > 1 {
+		t.Errorf("center pixel is %v, want %v within 1", got, want)
+	}
+}
+
+// TestDrawGradientBackground_UnknownColor checks that an unknown corner color is rejected
+func TestDrawGradientBackground_UnknownColor(t *testing.T) {
+	d := newDisplay(4, 4)
+	if err := d.DrawGradientBackground(NewColor("red"), NewColor("mauve"), NewColor("blue"), NewColor("white")); err != invalidColor {
+		t.Errorf("got %v, want invalidColor", err)
+	}
+}
+
+// TestDrawNoise_Seeded checks that the same seed draws the same noise on two independent displays
+// and that a different seed does not
+func TestDrawNoise_Seeded(t *testing.T) {
+	a, b, c := newDisplay(40, 30), newDisplay(40, 30), newDisplay(40, 30)
+	for _, step := range []struct {
+		d    *Display
+		seed int64
+	}{{a, 42}, {b, 42}, {c, 7}} {
+		if err := step.d.DrawNoise(0.3, step.seed); err != nil {
+			t.Fatalf("DrawNoise: %v", err)
+		}
+	}
+	if !a.Equal(b) {
+		t.Error("the same seed drew different noise")
+	}
+	if a.Equal(c) {
+		t.Error("different seeds drew the same noise")
+	}
+}
+
+// TestDrawSaltPepper_BlackAndWhite checks that salt and pepper noise only uses black and white
+func TestDrawSaltPepper_BlackAndWhite(t *testing.T) {
+	d := newDisplay(40, 30)
+	if err := d.DrawSaltPepper(1, 3); err != nil {
+		t.Fatalf("DrawSaltPepper: %v", err)
+	}
+	if black, white := d.Count(NewColor("black")), d.Count(NewColor("white")); black == 0 || black+white != 40*30 {
+		t.Errorf("got %d black and %d white pixels of %d", black, white, 40*30)
+	}
+}
+
+// TestDrawNoise_InvalidDensity checks that a density outside [0,1] is rejected
+func TestDrawNoise_InvalidDensity(t *testing.T) {
+	d := newDisplay(4, 4)
+	for _, density := range []float64{-0.1, 1.5} {
+		if err := d.DrawNoise(density, 1); err != errInvalidDensity {
+			t.Errorf("density %v: got %v, want errInvalidDensity", density, err)
+		}
+	}
+}
+
+// TestDrawMandelbrot_CanonicalView checks that the canonical view centered on (-0.5, 0) has both
+// a non-trivial fraction of escaping pixels and black pixels inside the set
+func TestDrawMandelbrot_CanonicalView(t *testing.T) {
+	d := newDisplay(600, 400)
+	if err := d.DrawMandelbrot(-0.5, 0, 200, 50); err != nil {
+		t.Fatalf("DrawMandelbrot: %v", err)
+	}
+	total := 600 * 400
+	inside := d.Count(NewColor("black"))
+	if escaped := total - inside; escaped < total/10 || inside < total/10 {
+		t.Errorf("got %d escaping and %d inside pixels of %d", escaped, inside, total)
+	}
+	if err := d.ComparePixel(300, 200, NewColor("black")); err != nil {
+		t.Errorf("center of the view: %v", err)
+	}
+}
+
+// TestDrawMandelbrot_InvalidParams checks the iteration count and zoom checks
+func TestDrawMandelbrot_InvalidParams(t *testing.T) {
+	d := newDisplay(4, 4)
+	if err := d.DrawMandelbrot(-0.5, 0, 200, 0); err != errInvalidIterations {
+		t.Errorf("maxIter 0: got %v, want errInvalidIterations", err)
+	}
+	if err := d.DrawMandelbrot(-0.5, 0, 0, 10); err != errInvalidZoom {
+		t.Errorf("zoom 0: got %v, want errInvalidZoom", err)
+	}
+}
+
+// TestDrawSineWave_FullPeriod checks that a full period of a sine wave starts and ends at yOffset
+// and peaks a quarter period in
+func TestDrawSineWave_FullPeriod(t *testing.T) {
+	red := NewColor("red")
+	d := newDisplay(101, 61)
+	if err := d.DrawSineWave(20, 1.0/100, 0, 30, red); err != nil {
+		t.Fatalf("DrawSineWave: %v", err)
+	}
+	for _, p := range []Point{{0, 30}, {100, 30}, {25, 50}, {75, 10}} {
+		if err := d.ComparePixel(p.x, p.y, red); err != nil {
+			t.Error(err)
+		}
+	}
+	if got := d.Count(red); got != 101 {
+		t.Errorf("got %d plotted pixels, want one per column (101)", got)
+	}
+}
+
+// TestDrawCosinePlot_PhaseShift checks that the cosine plot starts at its maximum
+func TestDrawCosinePlot_PhaseShift(t *testing.T) {
+	red := NewColor("red")
+	d := newDisplay(101, 61)
+	if err := d.DrawCosinePlot(20, 1.0/100, 0, 30, red); err != nil {
+		t.Fatalf("DrawCosinePlot: %v", err)
+	}
+	if err := d.ComparePixel(0, 50, red); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestDrawFunctionPlot_Line checks that the function plot maps the first and last columns to xMin and xMax
+func TestDrawFunctionPlot_Line(t *testing.T) {
+	red := NewColor("red")
+	d := newDisplay(11, 21)
+	if err := d.DrawFunctionPlot(func(x float64) float64 { return 2 * x }, 0, 10, red); err != nil {
+		t.Fatalf("DrawFunctionPlot: %v", err)
+	}
+	for x := 0; x <= 10; x++ {
+		if err := d.ComparePixel(x, 2*x, red); err != nil {
+			t.Error(err)
+		}
+	}
+	if err := d.DrawSineWave(1, 1, 0, 0, NewColor("mauve")); err != invalidColor {
+		t.Errorf("unknown color: got %v, want invalidColor", err)
+	}
+}
+
+// TestDrawSpiral_Archimedean checks that an Archimedean spiral from radius 0 to 50 over 3 turns
+// starts at the center and has its outermost pixels about 50 pixels away
+func TestDrawSpiral_Archimedean(t *testing.T) {
+	red := NewColor("red")
+	d := newDisplay(121, 121)
+	if err := d.DrawSpiral(60, 60, 3, 0, 50, red); err != nil {
+		t.Fatalf("DrawSpiral: %v", err)
+	}
+	for _, p := range []Point{{60, 60}, {110, 60}} {
+		if err := d.ComparePixel(p.x, p.y, red); err != nil {
+			t.Error(err)
+		}
+	}
+	var outer float64
+	d.PixelScan(func(x, y int, c Color) {
+		if dist := (Point{x, y}).Distance(Point{60, 60}); c == red && dist > outer {
+			outer = dist
+		}
+	})
+	if outer < 49 || outer > 51 {
+		t.Errorf("outermost pixel is %.2f from the center, want about 50", outer)
+	}
+}
+
+// TestDrawSpiral_Invalid checks the turn count and color checks, and that points off the display are skipped
+func TestDrawSpiral_Invalid(t *testing.T) {
+	d := newDisplay(20, 20)
+	if err := d.DrawSpiral(10, 10, 0, 0, 5, NewColor("red")); err != errInvalidTurns {
+		t.Errorf("0 turns: got %v, want errInvalidTurns", err)
+	}
+	if err := d.DrawSpiral(10, 10, 1, 0, 5, NewColor("mauve")); err != invalidColor {
+		t.Errorf("unknown color: got %v, want invalidColor", err)
+	}
+	if err := d.DrawSpiral(10, 10, 2, 0, 40, NewColor("red")); err != nil {
+		t.Errorf("spiral leaving the display: %v", err)
+	}
+}
+
+// TestDrawVoronoi_OneSeed checks that a single seed at the center colors the whole display
+func TestDrawVoronoi_OneSeed(t *testing.T) {
+	red := NewColor("red")
+	d := newDisplay(25, 15)
+	if err := d.DrawVoronoi([]Point{{12, 7}}, []Color{red}); err != nil {
+		t.Fatalf("DrawVoronoi: %v", err)
+	}
+	if got := d.Count(red); got != 25*15 {
+		t.Errorf("got %d red pixels, want all %d", got, 25*15)
+	}
+}
+
+// TestDrawVoronoi_OppositeCorners checks that seeds at opposite corners split the display along the
+// anti-diagonal, with the pixels on it going to the earlier seed
+func TestDrawVoronoi_OppositeCorners(t *testing.T) {
+	red, blue := NewColor("red"), NewColor("blue")
+	d := newDisplay(20, 20)
+	if err := d.DrawVoronoi([]Point{{0, 0}, {19, 19}}, []Color{red, blue}); err != nil {
+		t.Fatalf("DrawVoronoi: %v", err)
+	}
+	d.PixelScan(func(x, y int, c Color) {
+		want := blue
+		if x+y <= 19 {
+			want = red
+		}
+		if c != want {
+			t.Errorf("pixel (%d,%d) is %v, want %v", x, y, c, want)
+		}
+	})
+	if err := d.DrawVoronoi([]Point{{0, 0}, {19, 19}}, []Color{red}); err != errArgumentMismatch {
+		t.Errorf("one color for two seeds: got %v, want errArgumentMismatch", err)
+	}
+}
+
+// TestDrawSplineGrid_FlatGrid checks that an evenly spaced 3x2 grid of control points fills about
+// the rectangle it spans, and the control point count check
+func TestDrawSplineGrid_FlatGrid(t *testing.T) {
+	red := NewColor("red")
+	d := newDisplay(40, 30)
+	cp := make([][]Point, 4)
+	for i := range cp {
+		for j := 0; j <= 2; j++ {
+			cp[i] = append(cp[i], Point{5 + 10*i, 5 + 10*j})
+		}
+	}
+	if err := d.DrawSplineGrid(3, 2, cp, red); err != nil {
+		t.Fatalf("DrawSplineGrid: %v", err)
+	}
+	for _, err := range d.AssertRegion(6, 6, 34, 24, red) {
+		t.Error(err)
+	}
+	if got, want := d.Count(red), 31*21; abs(got-want) > 31+21 {
+		t.Errorf("got %d red pixels, want about the %d of the rectangle", got, want)
+	}
+	if got := d.CountInRegion(red, 0, 0, 39, 3) + d.CountInRegion(red, 0, 27, 39, 29); got != 0 {
+		t.Errorf("got %d red pixels outside the control points", got)
+	}
+
+	if err := d.DrawSplineGrid(3, 3, cp, red); err != errArgumentMismatch {
+		t.Errorf("3x3 patch with 4x3 points: got %v, want errArgumentMismatch", err)
+	}
+}
+
+// TestDrawFloodContour_RectangleInterior checks that the contour of the inside of an outlined
+// rectangle is the outline of the rectangle one pixel smaller, leaving the rest unchanged
+func TestDrawFloodContour_RectangleInterior(t *testing.T) {
+	red, blue := NewColor("red"), NewColor("blue")
+	d, want := newDisplay(20, 20), newDisplay(20, 20)
+	for _, disp := range []*Display{d, want} {
+		if err := NewRectangle(Point{5, 5}, Point{15, 12}, red).DrawOn(disp, DrawOutline); err != nil {
+			t.Fatalf("DrawOn: %v", err)
+		}
+	}
+	if err := NewRectangle(Point{6, 6}, Point{14, 11}, blue).DrawOn(want, DrawOutline); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+
+	if err := d.DrawFloodContour(10, 8, blue); err != nil {
+		t.Fatalf("DrawFloodContour: %v", err)
+	}
+	d.PixelScan(func(x, y int, c Color) {
+		if c != want.matrix[x][y] {
+			t.Errorf("pixel (%d,%d) is %v, want %v", x, y, c, want.matrix[x][y])
+		}
+	})
+	if err := d.DrawFloodContour(20, 8, blue); err != errOutOfBounds {
+		t.Errorf("seed off the display: got %v, want errOutOfBounds", err)
+	}
+}
+
+// TestDrawFilledCurve_FlatAndSine checks that f(x) = 0 over baseline 9 fills the same rows as a
+// rectangle, that a sine wave fills up to its peak and down to its trough, and that rows off the
+// display are skipped
+func TestDrawFilledCurve_FlatAndSine(t *testing.T) {
+	red := NewColor("red")
+	d, want := newDisplay(31, 21), newDisplay(31, 21)
+	if err := d.DrawFilledCurve(func(x float64) float64 { return 0 }, 0, 1, 9, red); err != nil {
+		t.Fatalf("DrawFilledCurve: %v", err)
+	}
+	want.SetBoundsMode(BoundsClip)
+	if err := NewRectangle(Point{0, 0}, Point{31, 10}, red).DrawOn(want, DrawFill); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+	if !d.Equal(want) {
+		t.Error("the flat curve does not match the rectangle")
+	}
+
+	// 41 columns over one period put the peak in column 10 and the trough in column 30
+	d = newDisplay(41, 21)
+	if err := d.DrawFilledCurve(func(x float64) float64 { return 10 + 5*math.Sin(x) }, 0, 2*math.Pi, 10, red); err != nil {
+		t.Fatalf("DrawFilledCurve: %v", err)
+	}
+	for _, c := range []struct{ x, lo, hi int }{{0, 10, 10}, {10, 10, 15}, {20, 10, 10}, {30, 5, 10}, {40, 10, 10}} {
+		for _, err := range d.AssertRegion(c.x, c.lo, c.x, c.hi, red) {
+			t.Error(err)
+		}
+		if got := d.CountInRegion(red, c.x, 0, c.x, 20); got != c.hi-c.lo+1 {
+			t.Errorf("column %d has %d red pixels, want rows %d to %d", c.x, got, c.lo, c.hi)
+		}
+	}
+
+	if err := d.DrawFilledCurve(func(x float64) float64 { return 100 }, 0, 1, -100, red); err != nil {
+		t.Errorf("curve and baseline off the display: %v", err)
+	}
+	if got := d.Count(red); got != 41*21 {
+		t.Errorf("a fill spanning the display colored %d pixels, want all %d", got, 41*21)
+	}
+	if err := d.DrawFilledCurve(func(x float64) float64 { return 0 }, 0, 1, 0, NewColor("mauve")); err != invalidColor {
+		t.Errorf("unknown color: got %v, want invalidColor", err)
+	}
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"os"
+)
+
+// ToImage converts the display to a standard library image, so that it can be used with any
+// package that accepts an image.Image; pixel (x,y) of the display is pixel (x,y) of the image
+// Unknown colors are written as black
+func (d *Display) ToImage() *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, d.maxX, d.maxY))
+	for x := 0; x < d.maxX; x++ {
+		for y := 0; y < d.maxY; y++ {
+			rgb, _ := colorToRGB(d.matrix[x][y])
+			img.SetNRGBA(x, y, color.NRGBA{uint8(rgb.R), uint8(rgb.G), uint8(rgb.B), 255})
+		}
+	}
+	return img
+}
+
+// colorFromImage converts a standard library color to the matching named Color,
+// or to an inline RGB Color if no named color matches
+// The alpha channel is ignored
+func colorFromImage(c color.Color) Color {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return colorFromRGB(RGB{int(n.R), int(n.G), int(n.B)})
+}
+
+// NewDisplayFromImage creates a display with the size and pixels of a standard library image
+// It is the inverse of ToImage; pixel (x,y) of the display comes from the image pixel at
+// (x,y) relative to the image's bounds
+// Returns errNilImage for a nil image or errEmptyDisplay for an image with no pixels
+func NewDisplayFromImage(img image.Image) (*Display, error) {
+	if img == nil {
+		return nil, errNilImage
+	}
+	b := img.Bounds()
+	if b.Empty() {
+		return nil, errEmptyDisplay
+	}
+	d := newDisplay(b.Dx(), b.Dy())
+	for x := 0; x < d.maxX; x++ {
+		for y := 0; y < d.maxY; y++ {
+			d.matrix[x][y] = colorFromImage(img.At(b.Min.X+x, b.Min.Y+y))
+		}
+	}
+	return d, nil
+}
+
+// DrawFromImage copies the pixels of a standard library image onto the display, with the
+// image's top-left pixel at (offsetX, offsetY); parts of the image outside the display are skipped
+// Colors are converted like in NewDisplayFromImage
+// Returns errNilImage for a nil image or errOutOfBounds if no part of the image lands on the display
+func (d *Display) DrawFromImage(img image.Image, offsetX, offsetY int) error {
+	if img == nil {
+		return errNilImage
+	}
+	b := img.Bounds()
+	x0, y0 := max(offsetX, 0), max(offsetY, 0)
+	x1, y1 := min(offsetX+b.Dx(), d.maxX), min(offsetY+b.Dy(), d.maxY)
+	if x0 >= x1 || y0 >= y1 {
+		return errOutOfBounds
+	}
+	for x := x0; x < x1; x++ {
+		for y := y0; y < y1; y++ {
+			d.matrix[x][y] = colorFromImage(img.At(b.Min.X+x-offsetX, b.Min.Y+y-offsetY))
+		}
+	}
+	return nil
+}
+
+// SavePNG saves the current state of the display to a PNG image file
+// Like screenShot, the extension is added to the given name
+// Returns fileError if there was a problem creating or writing to the file
+func (d *Display) SavePNG(f string) (err error) {
+	file, err := os.Create(f + ".png")
+	if err != nil {
+		return fileError
+	}
+	if err = png.Encode(file, d.ToImage()); err != nil {
+		file.Close()
+		return fileError
+	}
+	if err = file.Close(); err != nil {
+		return fileError
+	}
+	return nil
+}
+
+// SavePPM saves the current state of the display to a PPM image file
+// It is the exported form of screenShot; the extension is added to the given name
+// Returns fileError if there was a problem creating or writing to the file
+func (d *Display) SavePPM(f string) (err error) {
+	return d.screenShot(f)
+}
+
+// SaveBMP saves the current state of the display to an uncompressed 24-bit BMP image file
+// Like screenShot, the extension is added to the given name and row 0 is the top of the image
+// Returns fileError if there was a problem creating or writing to the file
+func (d *Display) SaveBMP(f string) (err error) {
+	// Every row is padded to a multiple of 4 bytes
+	stride := (3*d.maxX + 3) &^ 3
+	const headerSize = 14 + 40
+
+	var buf bytes.Buffer
+	buf.WriteString("BM")
+	for _, v := range []any{
+		// File header: size, two reserved fields and the offset of the pixel data
+		uint32(headerSize + stride*d.maxY), uint16(0), uint16(0), uint32(headerSize),
+		// Info header: size, width, height, planes, bits per pixel, no compression,
+		// image size, 2835 pixels per meter (72 DPI) and no palette
+		uint32(40), int32(d.maxX), int32(d.maxY), uint16(1), uint16(24), uint32(0),
+		uint32(stride * d.maxY), int32(2835), int32(2835), uint32(0), uint32(0),
+	} {
+		binary.Write(&buf, binary.LittleEndian, v)
+	}
+
+	// Pixel rows are stored bottom-up in blue, green, red order
+	row := make([]byte, stride)
+	for y := d.maxY - 1; y >= 0; y-- {
+		for x := 0; x < d.maxX; x++ {
+			rgb, _ := colorToRGB(d.matrix[x][y])
+			row[3*x], row[3*x+1], row[3*x+2] = byte(rgb.B), byte(rgb.G), byte(rgb.R)
+		}
+		buf.Write(row)
+	}
+
+	if err = os.WriteFile(f+".bmp", buf.Bytes(), 0644); err != nil {
+		return fileError
+	}
+	return nil
+}
+
+// savers maps each format supported by SaveFormats to the method that writes it
+var savers = map[string]func(d *Display, f string) error{
+	"ppm": (*Display).SavePPM,
+	"png": (*Display).SavePNG,
+	"bmp": (*Display).SaveBMP,
+}
+
+// SaveAll saves the display as basename.ppm, basename.png and basename.bmp
+// Returns one error per format in that order, nil for each file that was written
+func (d *Display) SaveAll(basename string) []error {
+	return d.SaveFormats(basename, []string{"ppm", "png", "bmp"})
+}
+
+// SaveFormats saves the display once per format ("ppm", "png" or "bmp") as basename.<format>
+// Returns one error per format in the same order, nil for each file that was written
+// and errUnknownFormat for an unsupported format
+func (d *Display) SaveFormats(basename string, formats []string) []error {
+	errs := make([]error, len(formats))
+	for i, format := range formats {
+		save, ok := savers[format]
+		if !ok {
+			errs[i] = errUnknownFormat
+			continue
+		}
+		errs[i] = save(d, basename)
+	}
+	return errs
+}
+
+// stripOf lays the receiver followed by frames out as a single film strip
+// orientation must be "vertical" (top to bottom) or "horizontal" (left to right)
+// Returns errNilDisplay for a nil frame, errDimensionMismatch if any frame is not the same
+// size as the receiver, or errInvalidOrientation
+func (d *Display) stripOf(frames []*Display, orientation string) (*Display, error) {
+	all := append([]*Display{d}, frames...)
+	for _, f := range all {
+		if f == nil {
+			return nil, errNilDisplay
+		}
+		if f.maxX != d.maxX || f.maxY != d.maxY {
+			return nil, errDimensionMismatch
+		}
+	}
+	switch orientation {
+	case "vertical":
+		return CombineVertical(all)
+	case "horizontal":
+		return CombineHorizontal(all)
+	}
+	return nil, errInvalidOrientation
+}
+
+// ExportFrames saves the receiver followed by frames as a single PPM sprite sheet
+// The frames are laid out as described by stripOf, and the ".ppm" extension is added to filename
+// Returns the errors of stripOf, or fileError if the file cannot be written
+func (d *Display) ExportFrames(frames []*Display, filename string, orientation string) error {
+	strip, err := d.stripOf(frames, orientation)
+	if err != nil {
+		return err
+	}
+	return strip.screenShot(filename)
+}
+
+// ExportFramesPNG is the PNG variant of ExportFrames
+// The ".png" extension is added to filename
+func (d *Display) ExportFramesPNG(frames []*Display, filename string, orientation string) error {
+	strip, err := d.stripOf(frames, orientation)
+	if err != nil {
+		return err
+	}
+	return strip.SavePNG(filename)
+}
+
+// toPaletted converts a copy of the display, quantized to at most 256 colors, to a paletted image
+// Returns errEmptyDisplay for a display with no pixels
+func (d *Display) toPaletted() (*image.Paletted, error) {
+	if d.maxX == 0 || d.maxY == 0 {
+		return nil, errEmptyDisplay
+	}
+	q := d.Clone()
+	if err := q.Quantize(256); err != nil {
+		return nil, err
+	}
+
+	var palette color.Palette
+	index := map[RGB]uint8{}
+	img := image.NewPaletted(image.Rect(0, 0, q.maxX, q.maxY), nil)
+	for x := 0; x < q.maxX; x++ {
+		for y := 0; y < q.maxY; y++ {
+			rgb, _ := colorToRGB(q.matrix[x][y])
+			i, exists := index[rgb]
+			if !exists {
+				i = uint8(len(palette))
+				index[rgb] = i
+				palette = append(palette, color.RGBA{uint8(rgb.R), uint8(rgb.G), uint8(rgb.B), 255})
+			}
+			img.Pix[img.PixOffset(x, y)] = i
+		}
+	}
+	img.Palette = palette
+	return img, nil
+}
+
+// ExportGIF saves the receiver followed by frames as an animated GIF that loops forever
+// Each frame is shown for delayMs milliseconds and the ".gif" extension is added to filename
+// Returns errNilDisplay or errEmptyDisplay for a bad frame, or fileError if the file cannot be written
+func (d *Display) ExportGIF(frames []*Display, delayMs int, filename string) error {
+	return d.ExportGIFLoop(frames, delayMs, 0, filename)
+}
+
+// ExportGIFLoop is ExportGIF with an explicit loop count
+// A loopCount of 0 loops forever and -1 plays the animation once
+func (d *Display) ExportGIFLoop(frames []*Display, delayMs, loopCount int, filename string) error {
+	anim := &gif.GIF{LoopCount: loopCount}
+	for _, f := range append([]*Display{d}, frames...) {
+		if f == nil {
+			return errNilDisplay
+		}
+		img, err := f.toPaletted()
+		if err != nil {
+			return err
+		}
+		anim.Image = append(anim.Image, img)
+		anim.Delay = append(anim.Delay, delayMs/10) // GIF delays are in 100ths of a second
+	}
+
+	file, err := os.Create(filename + ".gif")
+	if err != nil {
+		return fileError
+	}
+	if err = gif.EncodeAll(file, anim); err != nil {
+		file.Close()
+		return fileError
+	}
+	if err = file.Close(); err != nil {
+		return fileError
+	}
+	return nil
+}
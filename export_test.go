@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestExportFrames_ReadBack checks the dimensions of a 3-frame strip read back with ReadPPM
+func TestExportFrames_ReadBack(t *testing.T) {
+	frame := newDisplay(20, 15)
+	frame.matrix[4][5] = NewColor("red")
+	for _, tc := range []struct {
+		orientation string
+		w, h        int
+	}{{"vertical", 20, 45}, {"horizontal", 60, 15}} {
+		name := filepath.Join(t.TempDir(), "strip")
+		if err := frame.ExportFrames([]*Display{frame, frame}, name, tc.orientation); err != nil {
+			t.Fatalf("ExportFrames %s: %v", tc.orientation, err)
+		}
+		strip, err := ReadPPM(name + ".ppm")
+		if err != nil {
+			t.Fatalf("ReadPPM: %v", err)
+		}
+		if strip.maxX != tc.w || strip.maxY != tc.h {
+			t.Errorf("%s strip is %dx%d, want %dx%d", tc.orientation, strip.maxX, strip.maxY, tc.w, tc.h)
+		}
+		if err := strip.ComparePixel(4+tc.w-20, 5+tc.h-15, NewColor("red")); err != nil {
+			t.Errorf("%s strip, last frame: %v", tc.orientation, err)
+		}
+	}
+}
+
+// TestExportFrames_Invalid checks frames of another size and an unknown orientation
+func TestExportFrames_Invalid(t *testing.T) {
+	frame := newDisplay(20, 15)
+	name := filepath.Join(t.TempDir(), "strip")
+	if err := frame.ExportFrames([]*Display{newDisplay(20, 16)}, name, "vertical"); err != errDimensionMismatch {
+		t.Errorf("frame size: got %v, want errDimensionMismatch", err)
+	}
+	if err := frame.ExportFrames([]*Display{frame}, name, "diagonal"); err != errInvalidOrientation {
+		t.Errorf("orientation: got %v, want errInvalidOrientation", err)
+	}
+}
+
+// TestExportGIF_MovingCircle checks a 3-frame GIF of a circle moving to the right
+func TestExportGIF_MovingCircle(t *testing.T) {
+	frames := make([]*Display, 3)
+	for i := range frames {
+		frames[i] = newDisplay(60, 20)
+		if err := NewCircle(Point{10 + 20*i, 10}, 5, NewColor("red")).DrawOn(frames[i], DrawFill); err != nil {
+			t.Fatalf("DrawOn: %v", err)
+		}
+	}
+	name := filepath.Join(t.TempDir(), "anim")
+	if err := frames[0].ExportGIFLoop(frames[1:], 100, 2, name); err != nil {
+		t.Fatalf("ExportGIFLoop: %v", err)
+	}
+
+	file, err := os.Open(name + ".gif")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	anim, err := gif.DecodeAll(file)
+	if err != nil {
+		t.Fatalf("DecodeAll: %v", err)
+	}
+	if len(anim.Image) != 3 || anim.LoopCount != 2 {
+		t.Fatalf("got %d frames looping %d times, want 3 frames looping 2 times", len(anim.Image), anim.LoopCount)
+	}
+	red := color.RGBAModel.Convert(color.RGBA{255, 0, 0, 255})
+	for i, img := range anim.Image {
+		if anim.Delay[i] != 10 {
+			t.Errorf("frame %d: delay %d, want 10", i, anim.Delay[i])
+		}
+		for j := 0; j < 3; j++ {
+			got := color.RGBAModel.Convert(img.At(10+20*j, 10))
+			if (got == red) != (i == j) {
+				t.Errorf("frame %d: circle %d center is %v", i, j, got)
+			}
+		}
+	}
+}
+
+// TestExportGIF_NilFrame checks that a nil frame is rejected
+func TestExportGIF_NilFrame(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "anim")
+	if err := newDisplay(4, 4).ExportGIF([]*Display{nil}, 100, name); err != errNilDisplay {
+		t.Errorf("got %v, want errNilDisplay", err)
+	}
+}
+
+// TestSaveAll_ThreeFiles checks that SaveAll writes non-empty .ppm, .png and .bmp files
+func TestSaveAll_ThreeFiles(t *testing.T) {
+	d := newDisplay(8, 6)
+	base := filepath.Join(t.TempDir(), "all")
+	for i, err := range d.SaveAll(base) {
+		if err != nil {
+			t.Errorf("format %d: %v", i, err)
+		}
+	}
+	for _, ext := range []string{".ppm", ".png", ".bmp"} {
+		if info, err := os.Stat(base + ext); err != nil || info.Size() == 0 {
+			t.Errorf("%s: %v", base+ext, err)
+		}
+	}
+	if errs := d.SaveFormats(base, []string{"png", "tiff"}); errs[0] != nil || errs[1] != errUnknownFormat {
+		t.Errorf("SaveFormats: got %v, want [nil errUnknownFormat]", errs)
+	}
+}
+
+// TestToImage_RoundTrip checks that NewDisplayFromImage(d.ToImage()) equals d for named and inline colors
+func TestToImage_RoundTrip(t *testing.T) {
+	d := newDisplay(30, 20)
+	if err := d.DrawGradientBackground(NewColor("red"), NewColor("green"), NewColor("blue"), NewColor("white")); err != nil {
+		t.Fatalf("DrawGradientBackground: %v", err)
+	}
+	if err := NewCircle(Point{15, 10}, 6, NewColor("yellow")).DrawOn(d, DrawFill); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+	img := d.ToImage()
+	if b := img.Bounds(); b.Dx() != 30 || b.Dy() != 20 {
+		t.Fatalf("image is %dx%d, want 30x20", b.Dx(), b.Dy())
+	}
+	got, err := NewDisplayFromImage(img)
+	if err != nil {
+		t.Fatalf("NewDisplayFromImage: %v", err)
+	}
+	if !got.Equal(d) {
+		t.Error("the round trip changed the display")
+	}
+	if _, err := NewDisplayFromImage(nil); err != errNilImage {
+		t.Errorf("nil image: got %v, want errNilImage", err)
+	}
+}
+
+// TestDrawFromImage_DecodedPNG checks that a decoded PNG stamped onto a display keeps its pixel values,
+// is clipped at the display's edge and is rejected if it misses the display entirely
+func TestDrawFromImage_DecodedPNG(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 4, 3))
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 3; y++ {
+			src.SetNRGBA(x, y, color.NRGBA{uint8(60 * x), uint8(100 * y), 7, 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatalf("png.Encode: %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("png.Decode: %v", err)
+	}
+
+	d := newDisplay(10, 10)
+	if err := d.DrawFromImage(img, 7, 2); err != nil {
+		t.Fatalf("DrawFromImage: %v", err)
+	}
+	for x := 0; x < 3; x++ {
+		for y := 0; y < 3; y++ {
+			if err := d.ComparePixel(7+x, 2+y, NewColorRGB(60*x, 100*y, 7)); err != nil {
+				t.Error(err)
+			}
+		}
+	}
+	if got := d.Count(NewColor("white")); got != 100-9 {
+		t.Errorf("got %d white pixels, want the 91 not covered by the clipped image", got)
+	}
+	if err := d.DrawFromImage(img, 10, 0); err != errOutOfBounds {
+		t.Errorf("offset past the display: got %v, want errOutOfBounds", err)
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// intParam reads a whole number parameter from params
+// Accepts any Go integer type, or a float64 without a fraction as decoded from JSON
+// Returns errMissingParam or errInvalidParam, wrapped with the key name
+func intParam(params map[string]interface{}, key string) (int, error) {
+	v, ok := params[key]
+	if !ok {
+		return 0, fmt.Errorf("%s: %w", key, errMissingParam)
+	}
+	switch n := v.(type) {
+	case int:
+		return n, nil
+	case int32:
+		return int(n), nil
+	case int64:
+		return int(n), nil
+	case float64:
+		if n == math.Trunc(n) {
+			return int(n), nil
+		}
+	}
+	return 0, fmt.Errorf("%s: %w", key, errInvalidParam)
+}
+
+// pointParam reads the point stored under the keys xKey and yKey
+func pointParam(params map[string]interface{}, xKey, yKey string) (p Point, err error) {
+	if p.x, err = intParam(params, xKey); err != nil {
+		return Point{}, err
+	}
+	if p.y, err = intParam(params, yKey); err != nil {
+		return Point{}, err
+	}
+	return p, nil
+}
+
+// colorParam reads a color name parameter from params
+// An optional color that is missing is returned as the empty Color
+func colorParam(params map[string]interface{}, key string, required bool) (Color, error) {
+	v, ok := params[key]
+	if !ok {
+		if required {
+			return Color{}, fmt.Errorf("%s: %w", key, errMissingParam)
+		}
+		return Color{}, nil
+	}
+	name, ok := v.(string)
+	if !ok {
+		return Color{}, fmt.Errorf("%s: %w", key, errInvalidParam)
+	}
+	return NewColor(name), nil
+}
+
+// NewShape creates a shape from its type name and a map of parameters
+// Every shape needs "color" and may have "stroke"; the other keys are
+// "rectangle": "llx", "lly", "urx", "ury"
+// "triangle": "x0", "y0", "x1", "y1", "x2", "y2"
+// "circle": "cx", "cy", "r"
+// Returns errUnknownShapeType for an unknown type, or errMissingParam / errInvalidParam
+// wrapped with the name of the offending key
+func NewShape(shapeType string, params map[string]interface{}) (geometry, error) {
+	switch shapeType {
+	case "rectangle", "triangle", "circle":
+	default:
+		return nil, errUnknownShapeType
+	}
+
+	fill, err := colorParam(params, "color", true)
+	if err != nil {
+		return nil, err
+	}
+	stroke, err := colorParam(params, "stroke", false)
+	if err != nil {
+		return nil, err
+	}
+
+	switch shapeType {
+	case "rectangle":
+		r := Rectangle{c: fill, stroke: stroke, Thickness: 1}
+		if r.ll, err = pointParam(params, "llx", "lly"); err != nil {
+			return nil, err
+		}
+		if r.ur, err = pointParam(params, "urx", "ury"); err != nil {
+			return nil, err
+		}
+		return r, nil
+	case "triangle":
+		t := Triangle{c: fill, stroke: stroke, Thickness: 1}
+		if t.pt0, err = pointParam(params, "x0", "y0"); err != nil {
+			return nil, err
+		}
+		if t.pt1, err = pointParam(params, "x1", "y1"); err != nil {
+			return nil, err
+		}
+		if t.pt2, err = pointParam(params, "x2", "y2"); err != nil {
+			return nil, err
+		}
+		return t, nil
+	case "circle":
+		c := Circle{c: fill, stroke: stroke, Thickness: 1}
+		if c.center, err = pointParam(params, "cx", "cy"); err != nil {
+			return nil, err
+		}
+		if c.r, err = intParam(params, "r"); err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+	return nil, errUnknownShapeType // Not reached, the type was checked above
+}
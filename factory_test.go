@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+// factoryCases are valid NewShape parameters for each shape type and the shape they describe
+var factoryCases = []struct {
+	shapeType string
+	params    map[string]interface{}
+	want      geometry
+}{
+	{"rectangle", map[string]interface{}{"llx": 1, "lly": 2, "urx": 8, "ury": 9, "color": "red"},
+		NewRectangle(Point{1, 2}, Point{8, 9}, NewColor("red"))},
+	{"triangle", map[string]interface{}{"x0": 0, "y0": 0, "x1": 5, "y1": 5, "x2": 10, "y2": 0, "color": "green"},
+		NewTriangle(Point{0, 0}, Point{5, 5}, Point{10, 0}, NewColor("green"))},
+	{"circle", map[string]interface{}{"cx": 5.0, "cy": 5.0, "r": 3.0, "color": "blue", "stroke": "black"},
+		NewCircle(Point{5, 5}, 3, NewColor("blue")).WithStroke(NewColor("black"))},
+}
+
+// TestNewShape_AllTypes checks each shape type with correct parameters, including JSON-style float64 numbers
+func TestNewShape_AllTypes(t *testing.T) {
+	for _, tc := range factoryCases {
+		got, err := NewShape(tc.shapeType, tc.params)
+		if err != nil {
+			t.Errorf("%s: %v", tc.shapeType, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%s: got %+v, want %+v", tc.shapeType, got, tc.want)
+		}
+	}
+	if _, err := NewShape("hexagon", nil); err != errUnknownShapeType {
+		t.Errorf("unknown type: got %v, want errUnknownShapeType", err)
+	}
+}
+
+// TestNewShape_MissingParam checks that leaving out any one required key returns errMissingParam
+func TestNewShape_MissingParam(t *testing.T) {
+	for _, tc := range factoryCases {
+		for key := range tc.params {
+			if key == "stroke" {
+				continue // Optional
+			}
+			params := make(map[string]interface{}, len(tc.params))
+			for k, v := range tc.params {
+				if k != key {
+					params[k] = v
+				}
+			}
+			if _, err := NewShape(tc.shapeType, params); !errors.Is(err, errMissingParam) {
+				t.Errorf("%s without %q: got %v, want errMissingParam", tc.shapeType, key, err)
+			}
+		}
+	}
+	if _, err := NewShape("circle", map[string]interface{}{"cx": 1, "cy": 1, "r": 2.5, "color": "red"}); !errors.Is(err, errInvalidParam) {
+		t.Errorf("fractional radius: got %v, want errInvalidParam", err)
+	}
+}
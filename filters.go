@@ -0,0 +1,433 @@
+package main
+
+import (
+	"math"
+	"sort"
+)
+
+// colorDistance returns the squared Euclidean distance between two RGB values
+func colorDistance(a, b RGB) int {
+	dr, dg, db := a.R-b.R, a.G-b.G, a.B-b.B
+	return dr*dr + dg*dg + db*db
+}
+
+// nearestRGB returns the index of the palette entry closest to rgb
+func nearestRGB(rgb RGB, palette []RGB) (best int) {
+	for i, p := range palette {
+		if colorDistance(rgb, p) < colorDistance(rgb, palette[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+// colorCount is one distinct color of a display and how many pixels have it
+type colorCount struct {
+	rgb   RGB
+	count int
+}
+
+// channel returns component i (0 = R, 1 = G, 2 = B) of an RGB value
+func (rgb RGB) channel(i int) int {
+	return [3]int{rgb.R, rgb.G, rgb.B}[i]
+}
+
+// widestChannel returns the channel with the largest value range in the box and that range
+func widestChannel(box []colorCount) (channel, spread int) {
+	for ch := 0; ch < 3; ch++ {
+		lo, hi := 255, 0
+		for _, cc := range box {
+			lo = min(lo, cc.rgb.channel(ch))
+			hi = max(hi, cc.rgb.channel(ch))
+		}
+		if hi-lo > spread {
+			channel, spread = ch, hi-lo
+		}
+	}
+	return channel, spread
+}
+
+// medianCut builds a palette of at most n colors for the display
+// The distinct colors are repeatedly split at the pixel-weighted median of the box
+// with the widest channel, and each box is replaced by its weighted average color
+func (d *Display) medianCut(n int) []RGB {
+	counts := map[RGB]int{}
+	d.PixelScan(func(x, y int, c Color) {
+		rgb, _ := colorToRGB(c)
+		counts[rgb]++
+	})
+	var all []colorCount
+	for rgb, count := range counts {
+		all = append(all, colorCount{rgb, count})
+	}
+
+	// Sort so that the result does not depend on map ordering
+	sort.Slice(all, func(i, j int) bool {
+		a, b := all[i].rgb, all[j].rgb
+		if a.R != b.R {
+			return a.R < b.R
+		}
+		if a.G != b.G {
+			return a.G < b.G
+		}
+		return a.B < b.B
+	})
+
+	boxes := [][]colorCount{all}
+	for len(boxes) < n {
+		// Pick the box with the widest channel range
+		pick, channel, spread := -1, 0, 0
+		for i, box := range boxes {
+			if ch, s := widestChannel(box); s > spread {
+				pick, channel, spread = i, ch, s
+			}
+		}
+		if pick < 0 {
+			break
+		}
+
+		box := boxes[pick]
+		sort.SliceStable(box, func(i, j int) bool {
+			return box[i].rgb.channel(channel) < box[j].rgb.channel(channel)
+		})
+		total := 0
+		for _, cc := range box {
+			total += cc.count
+		}
+		split, seen := 1, box[0].count
+		for split < len(box)-1 && seen < total/2 {
+			seen += box[split].count
+			split++
+		}
+		boxes = append(boxes, box[split:])
+		boxes[pick] = box[:split]
+	}
+
+	var palette []RGB
+	for _, box := range boxes {
+		var r, g, b, total int
+		for _, cc := range box {
+			r += cc.rgb.R * cc.count
+			g += cc.rgb.G * cc.count
+			b += cc.rgb.B * cc.count
+			total += cc.count
+		}
+		if total > 0 {
+			palette = append(palette, RGB{(r + total/2) / total, (g + total/2) / total, (b + total/2) / total})
+		}
+	}
+	return palette
+}
+
+// Quantize reduces the display to at most n distinct colors using the median-cut algorithm
+// Every pixel is replaced by the nearest palette color, stored as an inline RGB Color
+// Returns errInvalidQuantize for n < 1
+func (d *Display) Quantize(n int) error {
+	if n < 1 {
+		return errInvalidQuantize
+	}
+	palette := d.medianCut(n)
+	return d.PixelWalk(func(x, y int, c Color) Color {
+		rgb, _ := colorToRGB(c)
+		p := palette[nearestRGB(rgb, palette)]
+		return NewColorRGB(p.R, p.G, p.B)
+	})
+}
+
+// QuantizeToPalette replaces every pixel with the nearest color of the given palette
+// Returns errInvalidQuantize for an empty palette or invalidColor if a palette color is unknown
+func (d *Display) QuantizeToPalette(palette []Color) error {
+	if len(palette) == 0 {
+		return errInvalidQuantize
+	}
+	rgbs := make([]RGB, len(palette))
+	for i, c := range palette {
+		var err error
+		if rgbs[i], err = colorToRGB(c); err != nil {
+			return invalidColor
+		}
+	}
+	return d.PixelWalk(func(x, y int, c Color) Color {
+		rgb, _ := colorToRGB(c)
+		return palette[nearestRGB(rgb, rgbs)]
+	})
+}
+
+// rgbMatrix decodes every pixel of the display to its RGB value
+// Unknown colors decode as black
+func (d *Display) rgbMatrix() [][]RGB {
+	m := make([][]RGB, d.maxX)
+	for x := range m {
+		m[x] = make([]RGB, d.maxY)
+		for y := range m[x] {
+			m[x][y], _ = colorToRGB(d.matrix[x][y])
+		}
+	}
+	return m
+}
+
+// MedianFilter returns a new display where each channel of every pixel is the median of
+// that channel over the (2*radius+1)² neighborhood around it
+// Pixels near the edges use only the neighbors that exist; the receiver is not modified
+// Returns errInvalidRadius for radius < 1
+func (d *Display) MedianFilter(radius int) (*Display, error) {
+	if radius < 1 {
+		return nil, errInvalidRadius
+	}
+	src := d.rgbMatrix()
+	out := newDisplay(d.maxX, d.maxY)
+
+	// One buffer per channel, reused for every pixel
+	size := (2*radius + 1) * (2*radius + 1)
+	rs, gs, bs := make([]int, 0, size), make([]int, 0, size), make([]int, 0, size)
+	for x := 0; x < d.maxX; x++ {
+		for y := 0; y < d.maxY; y++ {
+			rs, gs, bs = rs[:0], gs[:0], bs[:0]
+			for nx := max(x-radius, 0); nx <= min(x+radius, d.maxX-1); nx++ {
+				for ny := max(y-radius, 0); ny <= min(y+radius, d.maxY-1); ny++ {
+					rgb := src[nx][ny]
+					rs, gs, bs = append(rs, rgb.R), append(gs, rgb.G), append(bs, rgb.B)
+				}
+			}
+			sort.Ints(rs)
+			sort.Ints(gs)
+			sort.Ints(bs)
+			m := len(rs) / 2
+			out.matrix[x][y] = NewColorRGB(rs[m], gs[m], bs[m])
+		}
+	}
+	return out, nil
+}
+
+// luminance returns the perceived brightness (0-255) of an RGB value using the Rec. 601 weights
+func luminance(rgb RGB) int {
+	return int(math.Round(0.299*float64(rgb.R) + 0.587*float64(rgb.G) + 0.114*float64(rgb.B)))
+}
+
+// HistogramEqualization spreads the luminance of the display's pixels over the full 0-255 range
+// Each luminance L is remapped to (cdf(L) - cdfMin) / (pixels - cdfMin) * 255 and the channels
+// of the pixel are scaled by the same factor (black pixels become the new gray level)
+// Pixels are stored as inline RGB Colors; the display is modified in place
+// Returns errEmptyDisplay for a display with no pixels or errUniformDisplay if every pixel
+// has the same luminance
+func (d *Display) HistogramEqualization() error {
+	if d.maxX == 0 || d.maxY == 0 {
+		return errEmptyDisplay
+	}
+	src := d.rgbMatrix()
+	var hist [256]int
+	for x := range src {
+		for y := range src[x] {
+			hist[luminance(src[x][y])]++
+		}
+	}
+
+	// Cumulative distribution, and its value at the darkest level present
+	var cdf [256]int
+	cdfMin, total := 0, 0
+	for l, n := range hist {
+		total += n
+		cdf[l] = total
+		if cdfMin == 0 {
+			cdfMin = total
+		}
+	}
+	if total == cdfMin {
+		return errUniformDisplay
+	}
+
+	return d.PixelWalk(func(x, y int, c Color) Color {
+		rgb := src[x][y]
+		l := luminance(rgb)
+		eq := float64(cdf[l]-cdfMin) / float64(total-cdfMin) * 255
+		if l == 0 {
+			v := int(math.Round(eq))
+			return NewColorRGB(v, v, v)
+		}
+		scale := eq / float64(l)
+		return NewColorRGB(
+			int(math.Round(float64(rgb.R)*scale)),
+			int(math.Round(float64(rgb.G)*scale)),
+			int(math.Round(float64(rgb.B)*scale)),
+		)
+	})
+}
+
+// KernelEmboss3x3 is the kernel used by Emboss
+// Pixels come out light where the image gets brighter towards larger x and y and dark
+// where it gets darker; the weights sum to 0, so flat areas come out as the offset gray
+var KernelEmboss3x3 = [][]float64{
+	{-2, -1, 0},
+	{-1, 0, 1},
+	{0, 1, 2},
+}
+
+// ApplyKernelFilter returns a new display where each channel of every pixel is the sum of the
+// kernel weights times that channel over the neighborhood around it, plus offset
+// kernel[i][j] weighs the neighbor i-k columns and j-k rows away, where k is half the kernel side;
+// neighbors beyond the edges repeat the nearest edge pixel, and results are clamped to 0-255
+// Pixels are stored as inline RGB Colors; the receiver is not modified
+// Returns errInvalidKernel if the kernel is not a square with an odd side length
+func (d *Display) ApplyKernelFilter(kernel [][]float64, offset float64) (*Display, error) {
+	n := len(kernel)
+	if n%2 == 0 {
+		return nil, errInvalidKernel
+	}
+	for _, row := range kernel {
+		if len(row) != n {
+			return nil, errInvalidKernel
+		}
+	}
+	src := d.rgbMatrix()
+	out := newDisplay(d.maxX, d.maxY)
+	k := n / 2
+	for x := 0; x < d.maxX; x++ {
+		for y := 0; y < d.maxY; y++ {
+			r, g, b := offset, offset, offset
+			for i := range kernel {
+				for j, w := range kernel[i] {
+					rgb := src[max(0, min(x+i-k, d.maxX-1))][max(0, min(y+j-k, d.maxY-1))]
+					r += w * float64(rgb.R)
+					g += w * float64(rgb.G)
+					b += w * float64(rgb.B)
+				}
+			}
+			out.matrix[x][y] = NewColorRGB(int(math.Round(r)), int(math.Round(g)), int(math.Round(b)))
+		}
+	}
+	return out, nil
+}
+
+// Emboss returns a new display with a raised-relief effect on the edges of the shapes
+// Applies KernelEmboss3x3 with an offset of 128, so flat areas become mid gray
+func (d *Display) Emboss() (*Display, error) {
+	return d.ApplyKernelFilter(KernelEmboss3x3, 128)
+}
+
+// Posterize reduces every RGB channel of the display to the given number of equally spaced values
+// A channel value v falls in bucket v / (256/levels) and becomes bucket * 255 / (levels-1)
+// Pixels are stored as inline RGB Colors; the display is modified in place
+// Returns errInvalidLevels for levels < 2 or levels > 256
+func (d *Display) Posterize(levels int) error {
+	if levels < 2 || levels > 256 {
+		return errInvalidLevels
+	}
+	width := 256 / levels
+	step := func(v int) int {
+		return min(v/width, levels-1) * 255 / (levels - 1)
+	}
+	return d.PixelWalk(func(x, y int, c Color) Color {
+		rgb, _ := colorToRGB(c)
+		return NewColorRGB(step(rgb.R), step(rgb.G), step(rgb.B))
+	})
+}
+
+// ToPosterized returns a posterized copy of the display, leaving the receiver unchanged
+// Returns errInvalidLevels for levels < 2 or levels > 256
+func (d *Display) ToPosterized(levels int) (*Display, error) {
+	out := d.Clone()
+	if err := out.Posterize(levels); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FilterPipeline is a list of filters applied to a display one after another
+// Example: FilterPipeline{SepiaFilter(), SolarizeFilter(128)}.Apply(d)
+type FilterPipeline []func(*Display) error
+
+// Apply runs every filter of the pipeline on the display in order
+// Returns the first error reported by a filter, leaving the remaining filters unapplied
+func (fp FilterPipeline) Apply(d *Display) error {
+	for _, filter := range fp {
+		if err := filter(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SepiaFilter returns a filter that gives the display a sepia tone
+// Each pixel goes through the standard sepia matrix, with the channels clamped to 0-255:
+// R' = 0.393R + 0.769G + 0.189B, G' = 0.349R + 0.686G + 0.168B, B' = 0.272R + 0.534G + 0.131B
+func SepiaFilter() func(*Display) error {
+	return func(d *Display) error {
+		return d.PixelWalk(func(x, y int, c Color) Color {
+			rgb, _ := colorToRGB(c)
+			r, g, b := float64(rgb.R), float64(rgb.G), float64(rgb.B)
+			return NewColorRGB(
+				int(math.Round(0.393*r+0.769*g+0.189*b)),
+				int(math.Round(0.349*r+0.686*g+0.168*b)),
+				int(math.Round(0.272*r+0.534*g+0.131*b)),
+			)
+		})
+	}
+}
+
+// SolarizeFilter returns a filter that inverts every channel value above threshold (v becomes 255-v)
+// The filter returns errInvalidThreshold if threshold is outside [0,255]
+func SolarizeFilter(threshold int) func(*Display) error {
+	return func(d *Display) error {
+		if threshold < 0 || threshold > 255 {
+			return errInvalidThreshold
+		}
+		solarize := func(v int) int {
+			if v > threshold {
+				return 255 - v
+			}
+			return v
+		}
+		return d.PixelWalk(func(x, y int, c Color) Color {
+			rgb, _ := colorToRGB(c)
+			return NewColorRGB(solarize(rgb.R), solarize(rgb.G), solarize(rgb.B))
+		})
+	}
+}
+
+// PosterizeFilter returns a filter that calls Posterize with the given number of levels
+func PosterizeFilter(levels int) func(*Display) error {
+	return func(d *Display) error {
+		return d.Posterize(levels)
+	}
+}
+
+// Pixelate replaces every blockSize x blockSize block of the display with the average color of
+// the block, starting from pixel (0,0); blocks along the far edges are smaller when the display
+// size is not a multiple of blockSize
+// Pixels are stored as inline RGB Colors; the display is modified in place
+// Returns errInvalidBlockSize for blockSize < 1
+func (d *Display) Pixelate(blockSize int) error {
+	if blockSize < 1 {
+		return errInvalidBlockSize
+	}
+	src := d.rgbMatrix()
+	for bx := 0; bx < d.maxX; bx += blockSize {
+		for by := 0; by < d.maxY; by += blockSize {
+			x1, y1 := min(bx+blockSize, d.maxX), min(by+blockSize, d.maxY)
+			var r, g, b int
+			for x := bx; x < x1; x++ {
+				for y := by; y < y1; y++ {
+					r, g, b = r+src[x][y].R, g+src[x][y].G, b+src[x][y].B
+				}
+			}
+			n := (x1 - bx) * (y1 - by)
+			avg := NewColorRGB((r+n/2)/n, (g+n/2)/n, (b+n/2)/n)
+			for x := bx; x < x1; x++ {
+				for y := by; y < y1; y++ {
+					d.matrix[x][y] = avg
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ToPixelated returns a pixelated copy of the display, leaving the receiver unchanged
+// Returns errInvalidBlockSize for blockSize < 1
+func (d *Display) ToPixelated(blockSize int) (*Display, error) {
+	out := d.Clone()
+	if err := out.Pixelate(blockSize); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,256 @@
+package main
+
+import "testing"
+
+// gradient returns a w x h display filled with a four-corner gradient
+func gradient(t *testing.T, w, h int) *Display {
+	d := newDisplay(w, h)
+	if err := d.DrawGradientBackground(NewColor("red"), NewColor("yellow"), NewColor("blue"), NewColor("white")); err != nil {
+		t.Fatalf("DrawGradientBackground: %v", err)
+	}
+	return d
+}
+
+// distinctColors returns the number of different RGB values on the display
+func distinctColors(d *Display) int {
+	seen := map[RGB]bool{}
+	d.PixelScan(func(x, y int, c Color) {
+		rgb, _ := colorToRGB(c)
+		seen[rgb] = true
+	})
+	return len(seen)
+}
+
+// TestQuantizeToPalette_BiLevel checks that quantizing to black and white leaves only those two colors
+func TestQuantizeToPalette_BiLevel(t *testing.T) {
+	d := gradient(t, 32, 32)
+	if err := d.QuantizeToPalette([]Color{NewColor("black"), NewColor("white")}); err != nil {
+		t.Fatalf("QuantizeToPalette: %v", err)
+	}
+	black, white := d.Count(NewColor("black")), d.Count(NewColor("white"))
+	if black == 0 || white == 0 || black+white != 32*32 {
+		t.Errorf("got %d black and %d white pixels of %d", black, white, 32*32)
+	}
+}
+
+// TestQuantize_AtMostN checks that the median cut leaves at most n colors
+func TestQuantize_AtMostN(t *testing.T) {
+	for _, n := range []int{1, 4, 16} {
+		d := gradient(t, 32, 32)
+		if err := d.Quantize(n); err != nil {
+			t.Fatalf("Quantize(%d): %v", n, err)
+		}
+		if got := distinctColors(d); got > n {
+			t.Errorf("Quantize(%d) left %d colors", n, got)
+		}
+	}
+	d := newDisplay(4, 4)
+	if err := d.Quantize(0); err != errInvalidQuantize {
+		t.Errorf("Quantize(0): got %v, want errInvalidQuantize", err)
+	}
+	if err := d.QuantizeToPalette(nil); err != errInvalidQuantize {
+		t.Errorf("empty palette: got %v, want errInvalidQuantize", err)
+	}
+}
+
+// TestMedianFilter_RemovesNoise checks that a single noisy pixel in a uniform display takes the
+// color of its neighbors, and that the receiver is not modified
+func TestMedianFilter_RemovesNoise(t *testing.T) {
+	d := newDisplay(9, 9)
+	for x := 0; x < 9; x++ {
+		for y := 0; y < 9; y++ {
+			d.matrix[x][y] = NewColor("blue")
+		}
+	}
+	d.matrix[4][4] = NewColor("yellow")
+
+	out, err := d.MedianFilter(1)
+	if err != nil {
+		t.Fatalf("MedianFilter: %v", err)
+	}
+	if got := out.Count(NewColor("blue")); got != 81 {
+		t.Errorf("got %d blue pixels after filtering, want 81", got)
+	}
+	if err := d.ComparePixel(4, 4, NewColor("yellow")); err != nil {
+		t.Errorf("receiver changed: %v", err)
+	}
+	if _, err := d.MedianFilter(0); err != errInvalidRadius {
+		t.Errorf("radius 0: got %v, want errInvalidRadius", err)
+	}
+}
+
+// lumaRange returns the lowest and highest luminance on the display
+func lumaRange(d *Display) (lo, hi int) {
+	lo, hi = 255, 0
+	d.PixelScan(func(x, y int, c Color) {
+		rgb, _ := colorToRGB(c)
+		l := luminance(rgb)
+		lo, hi = min(lo, l), max(hi, l)
+	})
+	return lo, hi
+}
+
+// TestHistogramEqualization_GrayRamp checks that a low-contrast gray ramp is spread over the full range
+// with one pixel per level, and that a uniform display is rejected
+func TestHistogramEqualization_GrayRamp(t *testing.T) {
+	d := newDisplay(32, 4)
+	d.PixelWalk(func(x, y int, c Color) Color {
+		return NewColorRGB(100+x, 100+x, 100+x)
+	})
+	if lo, hi := lumaRange(d); hi-lo != 31 {
+		t.Fatalf("ramp spans %d..%d", lo, hi)
+	}
+	if err := d.HistogramEqualization(); err != nil {
+		t.Fatalf("HistogramEqualization: %v", err)
+	}
+	if lo, hi := lumaRange(d); lo != 0 || hi != 255 {
+		t.Errorf("equalized ramp spans %d..%d, want 0..255", lo, hi)
+	}
+	if got := distinctColors(d); got != 32 {
+		t.Errorf("got %d gray levels, want 32", got)
+	}
+	if err := newDisplay(8, 8).HistogramEqualization(); err != errUniformDisplay {
+		t.Errorf("uniform display: got %v, want errUniformDisplay", err)
+	}
+}
+
+// TestEmboss_Circle checks that a black circle gets a shadow on its low x side, a highlight on its
+// high x side and mid gray elsewhere
+func TestEmboss_Circle(t *testing.T) {
+	d := newDisplay(41, 41)
+	if err := NewCircle(Point{20, 20}, 8, NewColor("black")).DrawOn(d, DrawFill); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+	out, err := d.Emboss()
+	if err != nil {
+		t.Fatalf("Emboss: %v", err)
+	}
+	for _, tc := range []struct {
+		p    Point
+		want int
+	}{{Point{11, 20}, 0}, {Point{29, 20}, 255}, {Point{0, 0}, 128}, {Point{20, 20}, 128}} {
+		rgb, _ := colorToRGB(out.matrix[tc.p.x][tc.p.y])
+		if got := luminance(rgb); got != tc.want {
+			t.Errorf("luminance at %v is %d, want %d", tc.p, got, tc.want)
+		}
+	}
+}
+
+// TestPosterize_TwoLevels checks that two levels make every channel 0 or 255, the same as
+// thresholding each channel at 128, and that the immutable variant leaves its receiver alone
+func TestPosterize_TwoLevels(t *testing.T) {
+	d := gradient(t, 40, 40)
+	src := d.rgbMatrix()
+	out, err := d.ToPosterized(2)
+	if err != nil {
+		t.Fatalf("ToPosterized: %v", err)
+	}
+	threshold := func(v int) int {
+		if v >= 128 {
+			return 255
+		}
+		return 0
+	}
+	out.PixelScan(func(x, y int, c Color) {
+		got, _ := colorToRGB(c)
+		s := src[x][y]
+		if want := (RGB{threshold(s.R), threshold(s.G), threshold(s.B)}); got != want {
+			t.Errorf("pixel (%d,%d) %v became %v, want %v", x, y, s, got, want)
+		}
+	})
+	if !d.Equal(gradient(t, 40, 40)) {
+		t.Error("ToPosterized modified its receiver")
+	}
+	for _, levels := range []int{1, 257} {
+		if err := d.Posterize(levels); err != errInvalidLevels {
+			t.Errorf("%d levels: got %v, want errInvalidLevels", levels, err)
+		}
+	}
+}
+
+// pixelRGB returns the RGB value of the pixel (x,y) of the display
+func pixelRGB(d *Display, x, y int) RGB {
+	rgb, _ := colorToRGB(d.matrix[x][y])
+	return rgb
+}
+
+// TestSepiaFilter_Matrix checks the sepia transform of a red pixel and the clamping of a white one
+func TestSepiaFilter_Matrix(t *testing.T) {
+	d := newDisplay(2, 1)
+	d.matrix[0][0] = NewColor("red")
+	if err := SepiaFilter()(d); err != nil {
+		t.Fatalf("SepiaFilter: %v", err)
+	}
+	if got, want := pixelRGB(d, 0, 0), (RGB{100, 89, 69}); got != want {
+		t.Errorf("red became %v, want %v", got, want)
+	}
+	if got, want := pixelRGB(d, 1, 0), (RGB{255, 255, 239}); got != want {
+		t.Errorf("white became %v, want %v", got, want)
+	}
+}
+
+// TestSolarizeFilter_Threshold checks that only the channels above the threshold are inverted
+func TestSolarizeFilter_Threshold(t *testing.T) {
+	d := newDisplay(1, 1)
+	d.matrix[0][0] = NewColorRGB(200, 50, 128)
+	if err := SolarizeFilter(128)(d); err != nil {
+		t.Fatalf("SolarizeFilter: %v", err)
+	}
+	if got, want := pixelRGB(d, 0, 0), (RGB{55, 50, 128}); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if err := SolarizeFilter(256)(d); err != errInvalidThreshold {
+		t.Errorf("threshold 256: got %v, want errInvalidThreshold", err)
+	}
+}
+
+// TestFilterPipeline_Sequence checks that the filters run in order and that the first error stops the pipeline
+func TestFilterPipeline_Sequence(t *testing.T) {
+	d := newDisplay(1, 1)
+	if err := (FilterPipeline{SepiaFilter(), SolarizeFilter(128)}).Apply(d); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if got, want := pixelRGB(d, 0, 0), (RGB{0, 0, 16}); got != want {
+		t.Errorf("white became %v, want %v", got, want)
+	}
+
+	ran := false
+	err := FilterPipeline{SolarizeFilter(-1), func(*Display) error { ran = true; return nil }}.Apply(d)
+	if err != errInvalidThreshold || ran {
+		t.Errorf("got %v with the second filter run = %v, want errInvalidThreshold and false", err, ran)
+	}
+}
+
+// TestPixelate_SolidAndGradient checks that pixelating leaves a solid display unchanged and turns a
+// gradient into uniform blocks, with smaller blocks along the far edges
+func TestPixelate_SolidAndGradient(t *testing.T) {
+	solid := newDisplay(20, 20)
+	solid.PixelWalk(func(x, y int, c Color) Color { return NewColor("blue") })
+	if out, err := solid.ToPixelated(4); err != nil || !out.Equal(solid) {
+		t.Errorf("solid display changed (err %v)", err)
+	}
+
+	d := gradient(t, 30, 30)
+	out, err := d.ToPixelated(4)
+	if err != nil {
+		t.Fatalf("ToPixelated: %v", err)
+	}
+	// 7 full blocks and one 2-pixel block along each side
+	if got := distinctColors(out); got > 8*8 || got < 8*8/2 {
+		t.Errorf("got %d colors, want one per block (at most 64)", got)
+	}
+	out.PixelScan(func(x, y int, c Color) {
+		if corner := out.matrix[x/4*4][y/4*4]; !sameColor(c, corner) {
+			t.Errorf("pixel (%d,%d) differs from its block", x, y)
+		}
+	})
+	if sameColor(out.matrix[0][0], out.matrix[4][0]) {
+		t.Error("neighboring blocks of the gradient have the same color")
+	}
+	if !d.Equal(gradient(t, 30, 30)) {
+		t.Error("ToPixelated modified its receiver")
+	}
+	if err := d.Pixelate(0); err != errInvalidBlockSize {
+		t.Errorf("block size 0: got %v, want errInvalidBlockSize", err)
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+// This file keeps the old lowercase geometry methods working while callers move to
+// their exported replacements; new code should not use them
+
+// draw draws the triangle like DrawOn
+// Deprecated: Use DrawOn
+func (tri Triangle) draw(scn screen, mode DrawMode) (err error) {
+	return tri.DrawOn(scn, mode)
+}
+
+// printShape returns the same description as String
+// Deprecated: Use String, or format the shape with %v
+func (tri Triangle) printShape() (s string) {
+	return tri.String()
+}
+
+// draw draws the rectangle like DrawOn
+// Deprecated: Use DrawOn
+func (r Rectangle) draw(scn screen, mode DrawMode) (err error) {
+	return r.DrawOn(scn, mode)
+}
+
+// printShape returns the same description as String
+// Deprecated: Use String, or format the shape with %v
+func (r Rectangle) printShape() (s string) {
+	return r.String()
+}
+
+// draw draws the circle like DrawOn
+// Deprecated: Use DrawOn
+func (c Circle) draw(scn screen, mode DrawMode) (err error) {
+	return c.DrawOn(scn, mode)
+}
+
+// printShape returns the same description as String
+// Deprecated: Use String, or format the shape with %v
+func (c Circle) printShape() (s string) {
+	return c.String()
+}
+
+// draw draws the line like DrawOn
+// Deprecated: Use DrawOn
+func (l Line) draw(scn screen, mode DrawMode) (err error) {
+	return l.DrawOn(scn, mode)
+}
+
+// printShape returns the same description as String
+// Deprecated: Use String, or format the shape with %v
+func (l Line) printShape() (s string) {
+	return l.String()
+}
+
+// draw draws the polyline like DrawOn
+// Deprecated: Use DrawOn
+func (pl Polyline) draw(scn screen, mode DrawMode) (err error) {
+	return pl.DrawOn(scn, mode)
+}
+
+// printShape returns the same description as String
+// Deprecated: Use String, or format the shape with %v
+func (pl Polyline) printShape() (s string) {
+	return pl.String()
+}
+
+// draw draws the polygon like DrawOn
+// Deprecated: Use DrawOn
+func (pg Polygon) draw(scn screen, mode DrawMode) (err error) {
+	return pg.DrawOn(scn, mode)
+}
+
+// printShape returns the same description as String
+// Deprecated: Use String, or format the shape with %v
+func (pg Polygon) printShape() (s string) {
+	return pg.String()
+}
+
+// draw draws the parallelogram like DrawOn
+// Deprecated: Use DrawOn
+func (p Parallelogram) draw(scn screen, mode DrawMode) (err error) {
+	return p.DrawOn(scn, mode)
+}
+
+// printShape returns the same description as String
+// Deprecated: Use String, or format the shape with %v
+func (p Parallelogram) printShape() (s string) {
+	return p.String()
+}
@@ -0,0 +1,420 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// Line struct represents a straight line segment between two points
+// p0, p1: The end points, c: Line color, Thickness: Line width in pixels, at least 1
+type Line struct {
+	p0        Point // First end point
+	p1        Point // Second end point
+	c         Color // Line color
+	Thickness int   // Line width in pixels, at least 1
+}
+
+// Polyline struct represents an open chain of line segments through a list of points
+// points: The vertices in drawing order, c: Line color, Thickness: Line width in pixels, at least 1
+type Polyline struct {
+	points    []Point // Vertices in drawing order
+	c         Color   // Line color
+	Thickness int     // Line width in pixels, at least 1
+}
+
+// NewLine creates a Line of the given color from p0 to p1
+func NewLine(p0, p1 Point, c Color) Line {
+	return Line{p0: p0, p1: p1, c: c, Thickness: 1}
+}
+
+// NewPolyline creates a Polyline of the given color through the given points
+func NewPolyline(c Color, points ...Point) Polyline {
+	return Polyline{points: append([]Point(nil), points...), c: c, Thickness: 1}
+}
+
+// drawLine draws a straight line from p0 to p1 (inclusive) using Bresenham's algorithm
+// Returns the first error reported by the screen's drawPixel
+func drawLine(scn screen, p0, p1 Point, c Color) (err error) {
+	dx := p1.x - p0.x
+	if dx < 0 {
+		dx = -dx
+	}
+	dy := p1.y - p0.y
+	if dy > 0 {
+		dy = -dy
+	}
+	sx, sy := 1, 1
+	if p0.x > p1.x {
+		sx = -1
+	}
+	if p0.y > p1.y {
+		sy = -1
+	}
+
+	// e tracks the accumulated error of the ideal line against the pixel grid
+	x, y, e := p0.x, p0.y, dx+dy
+	for {
+		if err = scn.drawPixel(x, y, c); err != nil {
+			return err
+		}
+		if x == p1.x && y == p1.y {
+			return nil
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y += sy
+		}
+	}
+}
+
+// DrawLine draws a straight line from (x0,y0) to (x1,y1) inclusive
+// Returns errOutOfBounds if either endpoint is outside a display in BoundsError mode
+// Returns invalidColor if the color is not recognized
+func (d *Display) DrawLine(x0, y0, x1, y1 int, c Color) (err error) {
+	p0, p1 := Point{x0, y0}, Point{x1, y1}
+	if anyOutOfBounds(d, p0, p1) {
+		return errOutOfBounds
+	}
+	if colorUnknown(c) {
+		return invalidColor
+	}
+	return drawLine(d, p0, p1, c)
+}
+
+// dashState is the position along a dashed path, shared by copies of a dashScreen
+type dashState struct {
+	pos     int   // Number of pixels stepped along the path so far
+	prev    Point // Last pixel stepped on
+	started bool  // prev is set
+}
+
+// dashScreen wraps a screen so that the pixels drawn on it alternate between dash pixels
+// that are drawn and gap pixels that are skipped
+// A pixel repeated straight after itself, like the shared end point of two segments, only counts once
+type dashScreen struct {
+	screen
+	dash, gap int        // Lengths of the dashes and gaps in pixels
+	state     *dashState // Position along the path
+}
+
+// drawPixel draws the pixel on the wrapped screen if it falls on a dash
+func (ds dashScreen) drawPixel(x, y int, c Color) (err error) {
+	p := Point{x, y}
+	if ds.state.started && ds.state.prev == p {
+		return nil
+	}
+	ds.state.prev, ds.state.started = p, true
+	on := ds.state.pos%(ds.dash+ds.gap) < ds.dash
+	ds.state.pos++
+	if !on {
+		return nil
+	}
+	return ds.screen.drawPixel(x, y, c)
+}
+
+// clips is the dashScreen implementation of the clipper interface
+// Bounds are handled the same way as on the wrapped screen
+func (ds dashScreen) clips() bool {
+	return !checksBounds(ds.screen)
+}
+
+// DrawDashedLine draws a line from (x0,y0) to (x1,y1) inclusive, alternating dashLen drawn
+// pixels with gapLen skipped pixels along the Bresenham path, starting with a dash
+// Returns errInvalidDash for dashLen or gapLen < 1, or the same errors as DrawLine
+func (d *Display) DrawDashedLine(x0, y0, x1, y1, dashLen, gapLen int, c Color) error {
+	return d.DrawDashedPolyline([]Point{{x0, y0}, {x1, y1}}, dashLen, gapLen, c)
+}
+
+// DrawDashedPolyline draws lines between consecutive points with one dash pattern running
+// on across the corners, so a dash that reaches a corner continues on the next segment
+// Returns errInvalidDash for dashLen or gapLen < 1, errInvalidShape for fewer than 2 points,
+// errOutOfBounds if a point is outside a display in BoundsError mode, or invalidColor for an unknown color
+func (d *Display) DrawDashedPolyline(points []Point, dashLen, gapLen int, c Color) (err error) {
+	if dashLen < 1 || gapLen < 1 {
+		return errInvalidDash
+	}
+	if len(points) < 2 {
+		return errInvalidShape
+	}
+	if anyOutOfBounds(d, points...) {
+		return errOutOfBounds
+	}
+	if colorUnknown(c) {
+		return invalidColor
+	}
+	dashed := dashScreen{d, dashLen, gapLen, &dashState{}}
+	for i := 1; i < len(points); i++ {
+		if err = drawLine(dashed, points[i-1], points[i], c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// blendPixel blends the pixel at (x,y) alpha of the way towards rgb
+// Pixels outside the display are skipped
+func (d *Display) blendPixel(x, y int, rgb RGB, alpha float64) {
+	if x < 0 || y < 0 || x >= d.maxX || y >= d.maxY || alpha <= 0 {
+		return
+	}
+	cur, _ := colorToRGB(d.matrix[x][y])
+	d.matrix[x][y] = blendRGB(cur, rgb, alpha)
+}
+
+// DrawLineAA draws an antialiased line from (x0,y0) to (x1,y1) using Xiaolin Wu's algorithm
+// Each column (each row for steep lines) gets the two pixels bracketing the ideal line, blended
+// with the existing pixels in proportion to how close the line passes
+// Colors have no alpha channel, so the partial pixels are stored as inline colors mixed with
+// whatever was underneath; the line looks smooth in the PPM output only against that background
+// Returns the same errors as DrawLine
+func (d *Display) DrawLineAA(x0, y0, x1, y1 int, c Color) (err error) {
+	if anyOutOfBounds(d, Point{x0, y0}, Point{x1, y1}) {
+		return errOutOfBounds
+	}
+	rgb, err := colorToRGB(c)
+	if err != nil {
+		return invalidColor
+	}
+
+	steep := abs(y1-y0) > abs(x1-x0)
+	if steep {
+		x0, y0, x1, y1 = y0, x0, y1, x1
+	}
+	if x0 > x1 {
+		x0, y0, x1, y1 = x1, y1, x0, y0
+	}
+	plot := func(x, y int, alpha float64) {
+		if steep {
+			x, y = y, x
+		}
+		d.blendPixel(x, y, rgb, alpha)
+	}
+
+	gradient := 0.0
+	if x1 != x0 {
+		gradient = float64(y1-y0) / float64(x1-x0)
+	}
+	for x := x0; x <= x1; x++ {
+		y := float64(y0) + gradient*float64(x-x0)
+		base := math.Floor(y)
+		frac := y - base
+		plot(x, int(base), 1-frac)
+		plot(x, int(base)+1, frac)
+	}
+	return nil
+}
+
+// arrowHead returns the corners of an arrowhead with its tip at tip, pointing away from tail
+// The head is an isosceles triangle size pixels long with a base size pixels wide
+func arrowHead(tip, tail Point, size int) []Point {
+	angle := math.Atan2(float64(tip.y-tail.y), float64(tip.x-tail.x))
+	length, half := float64(size), float64(size)/2
+	bx := float64(tip.x) - length*math.Cos(angle)
+	by := float64(tip.y) - length*math.Sin(angle)
+	px, py := -half*math.Sin(angle), half*math.Cos(angle)
+	return []Point{
+		tip,
+		{int(math.Round(bx + px)), int(math.Round(by + py))},
+		{int(math.Round(bx - px)), int(math.Round(by - py))},
+	}
+}
+
+// DrawArrow draws a line from from to to with a filled arrowhead at to
+// The arrowhead is an isosceles triangle headSize pixels long with a base headSize pixels wide
+// Returns errInvalidHeadSize for headSize <= 0, errInvalidShape if from and to are the same point,
+// errOutOfBounds if the arrow does not fit on the display, or invalidColor for an unknown color
+func (d *Display) DrawArrow(from, to Point, headSize int, c Color) (err error) {
+	return d.drawArrow(from, to, headSize, c, false)
+}
+
+// DrawDoubleArrow draws a line between from and to with a filled arrowhead at both ends
+// Returns the same errors as DrawArrow
+func (d *Display) DrawDoubleArrow(from, to Point, headSize int, c Color) (err error) {
+	return d.drawArrow(from, to, headSize, c, true)
+}
+
+// drawArrow draws the line of an arrow and its head at to, and also at from if double is set
+func (d *Display) drawArrow(from, to Point, headSize int, c Color, double bool) (err error) {
+	if headSize <= 0 {
+		return errInvalidHeadSize
+	}
+	if from == to {
+		return errInvalidShape
+	}
+	heads := [][]Point{arrowHead(to, from, headSize)}
+	if double {
+		heads = append(heads, arrowHead(from, to, headSize))
+	}
+	for _, head := range heads {
+		if anyOutOfBounds(d, head...) {
+			return errOutOfBounds
+		}
+	}
+	if err = d.DrawLine(from.x, from.y, to.x, to.y, c); err != nil {
+		return err
+	}
+	for _, head := range heads {
+		if err = fillPolygon(d, head, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DrawOn is the Line implementation of the geometry.DrawOn method
+// A line has no interior, so every mode draws the same segment
+// Returns an error if an end point is out of bounds, the color is invalid or the thickness is less than 1
+func (l Line) DrawOn(scn screen, mode DrawMode) (err error) {
+	if !mode.valid() {
+		return errInvalidDrawMode
+	}
+	if anyOutOfBounds(scn, l.p0, l.p1) {
+		return errOutOfBounds
+	}
+	if colorUnknown(l.c) {
+		return invalidColor
+	}
+	size, err := brushSize(l.Thickness)
+	if err != nil {
+		return err
+	}
+	return drawLine(withThickness(scn, size), l.p0, l.p1, l.c)
+}
+
+// String is the Line implementation of the fmt.Stringer interface
+// Returns a string description of the line with its end points
+func (l Line) String() string {
+	return fmt.Sprintf("Line: (%d,%d) to (%d,%d)", l.p0.x, l.p0.y, l.p1.x, l.p1.y)
+}
+
+// Vertices is the Line implementation of the geometry.Vertices method
+// Returns the two end points
+func (l Line) Vertices() []Point {
+	return []Point{l.p0, l.p1}
+}
+
+// Centroid is the Line implementation of the geometry.Centroid method
+// Returns the midpoint of the line
+func (l Line) Centroid() Point {
+	return Point{(l.p0.x + l.p1.x) / 2, (l.p0.y + l.p1.y) / 2}
+}
+
+// DrawOn is the Polyline implementation of the geometry.DrawOn method
+// Draws a line between every pair of consecutive points
+// A polyline has no interior, so every mode draws the same line segments
+// Returns errInvalidShape if there are fewer than 2 points, or an error if any point
+// is out of bounds, the color is invalid or the thickness is less than 1
+func (pl Polyline) DrawOn(scn screen, mode DrawMode) (err error) {
+	if !mode.valid() {
+		return errInvalidDrawMode
+	}
+	if err = pl.Validate(); err != nil {
+		return err
+	}
+	if anyOutOfBounds(scn, pl.points...) {
+		return errOutOfBounds
+	}
+	if colorUnknown(pl.c) {
+		return invalidColor
+	}
+	size, err := brushSize(pl.Thickness)
+	if err != nil {
+		return err
+	}
+
+	brush := withThickness(scn, size)
+	for i := 1; i < len(pl.points); i++ {
+		if err = drawLine(brush, pl.points[i-1], pl.points[i], pl.c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// String is the Polyline implementation of the fmt.Stringer interface
+// Returns a string description of the polyline with its number of points
+func (pl Polyline) String() string {
+	return fmt.Sprintf("Polyline: %d points", len(pl.points))
+}
+
+// Vertices is the Polyline implementation of the geometry.Vertices method
+// Returns a copy of the polyline's points
+func (pl Polyline) Vertices() []Point {
+	return append([]Point(nil), pl.points...)
+}
+
+// Centroid is the Polyline implementation of the geometry.Centroid method
+// Returns the average of the polyline's points
+func (pl Polyline) Centroid() Point {
+	return averagePoint(pl.points)
+}
+
+// Close returns a Polygon through the same points, joining the last point back to the first
+// A repeated closing point at the end of the polyline is dropped
+func (pl Polyline) Close() Polygon {
+	points := pl.points
+	if n := len(points); n > 1 && points[0] == points[n-1] {
+		points = points[:n-1]
+	}
+	pg := NewPolygon(pl.c, points...)
+	pg.Thickness = pl.Thickness
+	return pg
+}
+
+// SmoothCatmullRom returns points along the Catmull-Rom spline through the polyline's points,
+// with steps subdivisions per segment; the curve passes through every original point
+// The end points are repeated to give the first and last segments their missing neighbor
+// Points are rounded to pixels and consecutive duplicates are dropped; steps below 1 are treated as 1
+func (pl Polyline) SmoothCatmullRom(steps int) []Point {
+	n := len(pl.points)
+	if n < 2 {
+		return pl.Vertices()
+	}
+	steps = max(steps, 1)
+	at := func(i int) (float64, float64) {
+		p := pl.points[max(0, min(i, n-1))]
+		return float64(p.x), float64(p.y)
+	}
+	spline := func(p0, p1, p2, p3, t float64) float64 {
+		return 0.5 * (2*p1 + (p2-p0)*t + (2*p0-5*p1+4*p2-p3)*t*t + (3*p1-p0-3*p2+p3)*t*t*t)
+	}
+
+	points := []Point{pl.points[0]}
+	for i := 0; i < n-1; i++ {
+		x0, y0 := at(i - 1)
+		x1, y1 := at(i)
+		x2, y2 := at(i + 1)
+		x3, y3 := at(i + 2)
+		for s := 1; s <= steps; s++ {
+			t := float64(s) / float64(steps)
+			p := Point{int(math.Round(spline(x0, x1, x2, x3, t))), int(math.Round(spline(y0, y1, y2, y3, t)))}
+			if p != points[len(points)-1] {
+				points = append(points, p)
+			}
+		}
+	}
+	return points
+}
+
+// DrawSmooth draws the Catmull-Rom spline through the polyline's points, using steps
+// subdivisions per segment, with the polyline's color and thickness
+// Returns errInvalidStep for steps < 1, or the same errors as DrawOn for the smoothed polyline
+func (pl Polyline) DrawSmooth(scn screen, steps int) (err error) {
+	if steps < 1 {
+		return errInvalidStep
+	}
+	if err = pl.Validate(); err != nil {
+		return err
+	}
+	smooth := pl
+	smooth.points = pl.SmoothCatmullRom(steps)
+	if len(smooth.points) == 1 {
+		smooth.points = append(smooth.points, smooth.points[0])
+	}
+	return smooth.DrawOn(scn, DrawDefault)
+}
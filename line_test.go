@@ -0,0 +1,256 @@
+package main
+
+import "testing"
+
+// TestLine_Thickness3 checks that a horizontal line of thickness 3 colors exactly 3 rows
+func TestLine_Thickness3(t *testing.T) {
+	d := newDisplay(40, 20)
+	l := NewLine(Point{5, 10}, Point{30, 10}, NewColor("red"))
+	l.Thickness = 3
+	if err := l.DrawOn(d, DrawDefault); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+
+	rows := 0
+	for y := 0; y < d.maxY; y++ {
+		row, err := d.ScanRow(y)
+		if err != nil {
+			t.Fatalf("ScanRow(%d): %v", y, err)
+		}
+		for _, c := range row {
+			if c.Name() == "red" {
+				rows++
+				break
+			}
+		}
+	}
+	if rows != 3 {
+		t.Errorf("got %d colored rows, want 3", rows)
+	}
+}
+
+// TestLine_InvalidThickness checks that a thickness below 1 is rejected
+func TestLine_InvalidThickness(t *testing.T) {
+	d := newDisplay(10, 10)
+	for _, thickness := range []int{0, -1} {
+		l := NewLine(Point{1, 1}, Point{8, 8}, NewColor("red"))
+		l.Thickness = thickness
+		if err := l.DrawOn(d, DrawDefault); err != errInvalidThickness {
+			t.Errorf("thickness %d: got %v, want errInvalidThickness", thickness, err)
+		}
+	}
+}
+
+// benchmarkLineThickness draws a 500-pixel diagonal line of the given thickness
+func benchmarkLineThickness(b *testing.B, thickness int) {
+	d := newDisplay(510, 510)
+	l := NewLine(Point{5, 5}, Point{504, 504}, NewColor("red"))
+	l.Thickness = thickness
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := l.DrawOn(d, DrawDefault); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLineThickness1(b *testing.B) { benchmarkLineThickness(b, 1) }
+func BenchmarkLineThickness5(b *testing.B) { benchmarkLineThickness(b, 5) }
+
+// TestPolyline_MatchesTriangleOutline checks that a polyline through the vertices of a triangle
+// draws the triangle's outline: the closed chain matches it exactly and the open one is part of it
+func TestPolyline_MatchesTriangleOutline(t *testing.T) {
+	a, b, c := Point{2, 2}, Point{17, 5}, Point{8, 16}
+	red := NewColor("red")
+	want := newDisplay(20, 20)
+	if err := NewTriangle(a, b, c, red).DrawOn(want, DrawOutline); err != nil {
+		t.Fatalf("Triangle.DrawOn: %v", err)
+	}
+
+	closed := newDisplay(20, 20)
+	if err := NewPolyline(red, a, b, c, a).DrawOn(closed, DrawDefault); err != nil {
+		t.Fatalf("Polyline.DrawOn: %v", err)
+	}
+	if !closed.Equal(want) {
+		t.Error("closed polyline does not match the triangle outline")
+	}
+
+	open := newDisplay(20, 20)
+	if err := NewPolyline(red, a, b, c).DrawOn(open, DrawDefault); err != nil {
+		t.Fatalf("Polyline.DrawOn: %v", err)
+	}
+	for x := 0; x < open.maxX; x++ {
+		for y := 0; y < open.maxY; y++ {
+			if open.matrix[x][y] == red && want.matrix[x][y] != red {
+				t.Errorf("open polyline pixel (%d,%d) is not on the triangle outline", x, y)
+			}
+		}
+	}
+}
+
+// TestPolyline_TooFewPoints checks that a polyline needs at least 2 points
+func TestPolyline_TooFewPoints(t *testing.T) {
+	d := newDisplay(10, 10)
+	if err := NewPolyline(NewColor("red"), Point{1, 1}).DrawOn(d, DrawDefault); err != errInvalidShape {
+		t.Errorf("got %v, want errInvalidShape", err)
+	}
+}
+
+// TestPolyline_String checks the polyline description
+func TestPolyline_String(t *testing.T) {
+	pl := NewPolyline(NewColor("red"), Point{1, 1}, Point{2, 2}, Point{3, 1})
+	if got, want := pl.String(), "Polyline: 3 points"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+// TestDrawArrow_Head checks that the arrowhead's tip is at to and that its area is about headSize²/2
+func TestDrawArrow_Head(t *testing.T) {
+	red := NewColor("red")
+	d := newDisplay(50, 40)
+	if err := d.DrawArrow(Point{5, 20}, Point{40, 20}, 10, red); err != nil {
+		t.Fatalf("DrawArrow: %v", err)
+	}
+	if err := d.ComparePixel(40, 20, red); err != nil {
+		t.Errorf("tip: %v", err)
+	}
+	if err := d.ComparePixel(41, 20, NewColor("white")); err != nil {
+		t.Errorf("past the tip: %v", err)
+	}
+	// The head covers x 30..40; rasterizing its edges adds about one pixel per column
+	if got := d.CountInRegion(red, 30, 0, 40, 39); got < 50 || got > 50+2*11 {
+		t.Errorf("arrowhead covers %d pixels, want about 50", got)
+	}
+
+	d = newDisplay(50, 40)
+	if err := d.DrawDoubleArrow(Point{5, 20}, Point{40, 20}, 10, red); err != nil {
+		t.Fatalf("DrawDoubleArrow: %v", err)
+	}
+	if tail, head := d.CountInRegion(red, 5, 0, 15, 39), d.CountInRegion(red, 30, 0, 40, 39); tail != head {
+		t.Errorf("the two heads cover %d and %d pixels", tail, head)
+	}
+	if err := d.DrawArrow(Point{5, 20}, Point{40, 20}, 0, red); err != errInvalidHeadSize {
+		t.Errorf("head size 0: got %v, want errInvalidHeadSize", err)
+	}
+}
+
+// TestPolyline_SmoothCatmullRom checks that the spline through 4 points with 100 steps per segment
+// passes through all of them and moves at most one pixel at a time
+func TestPolyline_SmoothCatmullRom(t *testing.T) {
+	red := NewColor("red")
+	control := []Point{{5, 5}, {30, 40}, {60, 10}, {90, 45}}
+	pl := NewPolyline(red, control...)
+	curve := pl.SmoothCatmullRom(100)
+	on := map[Point]bool{}
+	for i, p := range curve {
+		on[p] = true
+		if i > 0 && (abs(p.x-curve[i-1].x) > 1 || abs(p.y-curve[i-1].y) > 1) {
+			t.Errorf("the curve jumps from %v to %v", curve[i-1], p)
+		}
+	}
+	for _, p := range control {
+		if !on[p] {
+			t.Errorf("the curve misses control point %v", p)
+		}
+	}
+	if curve[0] != control[0] || curve[len(curve)-1] != control[3] {
+		t.Errorf("the curve runs from %v to %v", curve[0], curve[len(curve)-1])
+	}
+
+	d := newDisplay(100, 50)
+	if err := pl.DrawSmooth(d, 100); err != nil {
+		t.Fatalf("DrawSmooth: %v", err)
+	}
+	for _, p := range control {
+		if err := d.ComparePixel(p.x, p.y, red); err != nil {
+			t.Error(err)
+		}
+	}
+	if err := pl.DrawSmooth(d, 0); err != errInvalidStep {
+		t.Errorf("0 steps: got %v, want errInvalidStep", err)
+	}
+}
+
+// TestDrawLineAA_Diagonal checks that a shallow diagonal drawn with DrawLineAA has pixels between
+// black and white where DrawLine has only black ones
+func TestDrawLineAA_Diagonal(t *testing.T) {
+	black := NewColor("black")
+	aa, plain := newDisplay(31, 12), newDisplay(31, 12)
+	if err := aa.DrawLineAA(0, 0, 30, 10, black); err != nil {
+		t.Fatalf("DrawLineAA: %v", err)
+	}
+	if err := plain.DrawLine(0, 0, 30, 10, black); err != nil {
+		t.Fatalf("DrawLine: %v", err)
+	}
+
+	partial := func(d *Display) int {
+		n := 0
+		d.PixelScan(func(x, y int, c Color) {
+			if rgb, _ := colorToRGB(c); rgb.R > 0 && rgb.R < 255 {
+				n++
+			}
+		})
+		return n
+	}
+	// 20 of the 31 columns fall between two rows and split the ink between both of them
+	if got := partial(aa); got != 40 {
+		t.Errorf("DrawLineAA drew %d intermediate pixels, want 40", got)
+	}
+	if got := partial(plain); got != 0 {
+		t.Errorf("DrawLine drew %d intermediate pixels, want none", got)
+	}
+	for _, p := range []Point{{0, 0}, {15, 5}, {30, 10}} {
+		if err := aa.ComparePixel(p.x, p.y, black); err != nil {
+			t.Errorf("pixel exactly on the line: %v", err)
+		}
+	}
+	if err := aa.DrawLineAA(0, 0, 40, 10, black); err != errOutOfBounds {
+		t.Errorf("endpoint off the display: got %v, want errOutOfBounds", err)
+	}
+}
+
+// TestDrawDashedLine_HalfDrawn checks that a 100-pixel horizontal line with 5-pixel dashes and gaps
+// colors exactly 50 pixels, starting with a dash, and that the pattern runs on across polyline corners
+func TestDrawDashedLine_HalfDrawn(t *testing.T) {
+	red := NewColor("red")
+	d := newDisplay(100, 10)
+	if err := d.DrawDashedLine(0, 5, 99, 5, 5, 5, red); err != nil {
+		t.Fatalf("DrawDashedLine: %v", err)
+	}
+	if got := d.Count(red); got != 50 {
+		t.Errorf("got %d colored pixels, want 50", got)
+	}
+	for x := 0; x < 100; x++ {
+		want := NewColor("white")
+		if x%10 < 5 {
+			want = red
+		}
+		if err := d.ComparePixel(x, 5, want); err != nil {
+			t.Error(err)
+		}
+	}
+	for _, gap := range []int{0, -5} {
+		if err := d.DrawDashedLine(0, 5, 99, 5, 5, gap, red); err != errInvalidDash {
+			t.Errorf("gap %d: got %v, want errInvalidDash", gap, err)
+		}
+	}
+
+	// The corner (2,0) is the third pixel of the first dash, which runs on for two more pixels
+	// down the second segment; the shared corner only counts once
+	d = newDisplay(10, 10)
+	if err := d.DrawDashedPolyline([]Point{{0, 0}, {2, 0}, {2, 9}}, 5, 5, red); err != nil {
+		t.Fatalf("DrawDashedPolyline: %v", err)
+	}
+	for _, err := range d.AssertRegion(0, 0, 2, 0, red) {
+		t.Error(err)
+	}
+	for _, err := range d.AssertRegion(2, 1, 2, 2, red) {
+		t.Error(err)
+	}
+	for _, err := range d.AssertRegion(2, 3, 2, 7, NewColor("white")) {
+		t.Error(err)
+	}
+	if got := d.Count(red); got != 7 {
+		t.Errorf("got %d colored pixels on the polyline, want 7", got)
+	}
+}
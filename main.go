@@ -11,6 +11,7 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"strings"
 )
 
@@ -42,6 +43,7 @@ func main() {
 		fmt.Println("\t R for a rectangle")
 		fmt.Println("\t T for a triangle")
 		fmt.Println("\t C for a circle")
+		fmt.Println("\t P for a polyline")
 		fmt.Println(" or X to stop drawing shapes.")
 
 		var choice string
@@ -64,20 +66,22 @@ func main() {
 			shape, err = drawTriangle()
 		case "C", "c":
 			shape, err = drawCircle()
+		case "P", "p":
+			shape, err = drawPolyline()
 		default:
 			fmt.Println("Invalid choice, please try again.")
 			continue
 		}
 
 		// Print the shape and attempt to draw it
-		fmt.Println(shape.printShape())
+		fmt.Printf("%v\n", shape)
 
 		// Draw the shape on the display
-		err = shape.draw(&d)
+		err = shape.DrawOn(&d, DrawDefault)
 		if err != nil {
 			fmt.Printf("**Error: %v\n", err)
 		} else {
-			shapeName := getShapeName(shape.printShape())
+			shapeName := getShapeName(fmt.Sprintf("%v", shape))
 			fmt.Printf("%s drawn successfully.\n", shapeName)
 		}
 	}
@@ -95,7 +99,7 @@ func main() {
 	}
 }
 
-// getShapeName extracts the shape name from the printShape() output
+// getShapeName extracts the shape name from the shape's String() output
 // Used for user feedback after drawing a shape
 func getShapeName(shapeDescription string) string {
 	// Find the shape name before the colon
@@ -106,6 +110,32 @@ func getShapeName(shapeDescription string) string {
 	return "Shape" // Default fallback
 }
 
+// readLine reads the rest of the current input line from standard input
+// Reads byte by byte so that no input is buffered away from later fmt.Scan calls
+// Returns the line with surrounding whitespace removed, empty if the user just pressed Enter
+func readLine() string {
+	var line []byte
+	b := make([]byte, 1)
+	for {
+		n, err := os.Stdin.Read(b)
+		if n == 0 || err != nil || b[0] == '\n' {
+			break
+		}
+		line = append(line, b[0])
+	}
+	return strings.TrimSpace(string(line))
+}
+
+// readStroke prompts for an optional outline color for the named shape
+// Returns the empty Color if the user just pressed Enter
+func readStroke(shapeName string) Color {
+	fmt.Printf("Enter the outline color of the %s (press Enter for none): ", shapeName)
+	if name := readLine(); name != "" {
+		return NewColor(name)
+	}
+	return Color{}
+}
+
 // drawRectangle prompts the user for rectangle parameters and creates a Rectangle
 // Returns a Rectangle object implementing the geometry interface and any error encountered
 func drawRectangle() (geometry, error) {
@@ -120,16 +150,19 @@ func drawRectangle() (geometry, error) {
 
 	fmt.Print("Enter the color of the rectangle: ")
 	fmt.Scan(&colorName)
+	stroke := readStroke("rectangle")
 
 	// Create the rectangle
 	r := Rectangle{
-		ll: Point{llx, lly},
-		ur: Point{urx, ury},
-		c:  Color{colorName},
+		ll:        Point{llx, lly},
+		ur:        Point{urx, ury},
+		c:         NewColor(colorName),
+		stroke:    stroke,
+		Thickness: 1,
 	}
 
-	// Check if color is valid
-	if colorUnknown(r.c) {
+	// Check if colors are valid
+	if colorUnknown(r.c) || (r.stroke != Color{} && colorUnknown(r.stroke)) {
 		return r, invalidColor
 	}
 
@@ -153,17 +186,20 @@ func drawTriangle() (geometry, error) {
 
 	fmt.Print("Enter the color of the triangle: ")
 	fmt.Scan(&colorName)
+	stroke := readStroke("triangle")
 
 	// Create the triangle
 	t := Triangle{
-		pt0: Point{x0, y0},
-		pt1: Point{x1, y1},
-		pt2: Point{x2, y2},
-		c:   Color{colorName},
+		pt0:       Point{x0, y0},
+		pt1:       Point{x1, y1},
+		pt2:       Point{x2, y2},
+		c:         NewColor(colorName),
+		stroke:    stroke,
+		Thickness: 1,
 	}
 
-	// Check if color is valid
-	if colorUnknown(t.c) {
+	// Check if colors are valid
+	if colorUnknown(t.c) || (t.stroke != Color{} && colorUnknown(t.stroke)) {
 		return t, invalidColor
 	}
 
@@ -184,18 +220,52 @@ func drawCircle() (geometry, error) {
 
 	fmt.Print("Enter the color of the circle: ")
 	fmt.Scan(&colorName)
+	stroke := readStroke("circle")
 
 	// Create the circle
 	c := Circle{
-		center: Point{centerX, centerY},
-		r:      radius,
-		c:      Color{colorName},
+		center:    Point{centerX, centerY},
+		r:         radius,
+		c:         NewColor(colorName),
+		stroke:    stroke,
+		Thickness: 1,
 	}
 
-	// Check if color is valid
-	if colorUnknown(c.c) {
+	// Check if colors are valid
+	if colorUnknown(c.c) || (c.stroke != Color{} && colorUnknown(c.stroke)) {
 		return c, invalidColor
 	}
 
 	return c, nil
 }
+
+// drawPolyline prompts the user for polyline parameters and creates a Polyline
+// Returns a Polyline object implementing the geometry interface and any error encountered
+func drawPolyline() (geometry, error) {
+	var n int
+	var colorName string
+
+	fmt.Print("Enter the number of points of the polyline: ")
+	fmt.Scan(&n)
+
+	points := make([]Point, 0, max(n, 0))
+	for i := 0; i < n; i++ {
+		var x, y int
+		fmt.Printf("Enter the X and Y values of point %d of the polyline: ", i+1)
+		fmt.Scan(&x, &y)
+		points = append(points, Point{x, y})
+	}
+
+	fmt.Print("Enter the color of the polyline: ")
+	fmt.Scan(&colorName)
+
+	// Create the polyline
+	pl := NewPolyline(NewColor(colorName), points...)
+
+	// Check if color is valid
+	if colorUnknown(pl.c) {
+		return pl, invalidColor
+	}
+
+	return pl, nil
+}
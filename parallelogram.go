@@ -0,0 +1,105 @@
+package main
+
+import "fmt"
+
+// Parallelogram struct represents a parallelogram with horizontal top and bottom edges
+// ll: Lower-left corner, width: Length of the horizontal edges, height: Vertical distance
+// between them, shear: Horizontal shift of the top edge against the bottom one, c: Fill color
+// Thickness: Outline width in pixels, at least 1
+// A shear of 0 gives an axis-aligned rectangle
+type Parallelogram struct {
+	ll        Point // Lower-left corner
+	width     int   // Length of the bottom and top edges
+	height    int   // Distance between the bottom and top edges
+	shear     int   // Horizontal offset of the top edge
+	c         Color // Fill color
+	Thickness int   // Outline width in pixels, at least 1
+}
+
+// NewParallelogram creates a Parallelogram of the given color
+func NewParallelogram(ll Point, width, height, shear int, c Color) Parallelogram {
+	return Parallelogram{ll: ll, width: width, height: height, shear: shear, c: c, Thickness: 1}
+}
+
+// polygon returns the parallelogram as a Polygon with the same vertices, color and thickness
+func (p Parallelogram) polygon() Polygon {
+	return Polygon{points: p.Vertices(), c: p.c, Thickness: p.Thickness}
+}
+
+// DrawOn is the Parallelogram implementation of the geometry.DrawOn method
+// Draws the parallelogram with the Polygon scanline fill and/or its edges
+// Returns errInvalidShape for a non-positive width or height, or an error if the
+// parallelogram is out of bounds or if the color is invalid
+func (p Parallelogram) DrawOn(scn screen, mode DrawMode) (err error) {
+	if !mode.valid() {
+		return errInvalidDrawMode
+	}
+	if err = p.Validate(); err != nil {
+		return err
+	}
+	return p.polygon().DrawOn(scn, mode)
+}
+
+// String is the Parallelogram implementation of the fmt.Stringer interface
+// Returns a string description of the parallelogram with its corner, size and shear
+func (p Parallelogram) String() string {
+	return fmt.Sprintf("Parallelogram: ll=(%d,%d) w=%d h=%d shear=%d", p.ll.x, p.ll.y, p.width, p.height, p.shear)
+}
+
+// Vertices is the Parallelogram implementation of the geometry.Vertices method
+// Returns the corners counter-clockwise from the lower-left one
+func (p Parallelogram) Vertices() []Point {
+	return []Point{
+		p.ll,
+		{p.ll.x + p.width, p.ll.y},
+		{p.ll.x + p.width + p.shear, p.ll.y + p.height},
+		{p.ll.x + p.shear, p.ll.y + p.height},
+	}
+}
+
+// Centroid is the Parallelogram implementation of the geometry.Centroid method
+// Returns the point where the diagonals cross
+func (p Parallelogram) Centroid() Point {
+	return Point{p.ll.x + (p.width+p.shear)/2, p.ll.y + p.height/2}
+}
+
+// BoundingBox is the Parallelogram implementation of the geometry.BoundingBox method
+func (p Parallelogram) BoundingBox() Box {
+	return boxOf(p.Vertices())
+}
+
+// Contains is the Parallelogram implementation of the geometry.Contains method
+func (p Parallelogram) Contains(pt Point) bool {
+	return p.polygon().Contains(pt)
+}
+
+// Intersects is the Parallelogram implementation of the geometry.Intersects method
+func (p Parallelogram) Intersects(other geometry) bool {
+	return intersects(p, other)
+}
+
+// Validate is the Parallelogram implementation of the geometry.Validate method
+// Returns errInvalidShape if the width or the height is not positive
+func (p Parallelogram) Validate() error {
+	if p.width <= 0 || p.height <= 0 {
+		return errInvalidShape
+	}
+	return nil
+}
+
+// Normalize is the Parallelogram implementation of the geometry.Normalize method
+// A parallelogram is already stored in canonical form
+func (p Parallelogram) Normalize() geometry {
+	return p
+}
+
+// OffsetBy is the Parallelogram implementation of the geometry.OffsetBy method
+func (p Parallelogram) OffsetBy(dx, dy int) geometry {
+	p.ll = Point{p.ll.x + dx, p.ll.y + dy}
+	return p
+}
+
+// Offset is the Parallelogram implementation of the geometry.Offset method
+func (p Parallelogram) Offset(delta Point) geometry {
+	return p.OffsetBy(delta.x, delta.y)
+}
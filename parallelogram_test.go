@@ -0,0 +1,48 @@
+package main
+
+import "testing"
+
+// TestParallelogram_NoShear checks that a zero shear draws the same pixels as the polygon of an
+// axis-aligned rectangle and that a sheared one keeps its area
+func TestParallelogram_NoShear(t *testing.T) {
+	red := NewColor("red")
+	p := NewParallelogram(Point{2, 3}, 10, 6, 0, red)
+	d, want := newDisplay(30, 20), newDisplay(30, 20)
+	if err := p.DrawOn(d, DrawFill); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+	if err := NewPolygon(red, Point{2, 3}, Point{12, 3}, Point{12, 9}, Point{2, 9}).DrawOn(want, DrawFill); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+	if !d.Equal(want) {
+		t.Error("the unsheared parallelogram differs from the rectangle")
+	}
+
+	sheared := newDisplay(30, 20)
+	if err := NewParallelogram(Point{2, 3}, 10, 6, 5, red).DrawOn(sheared, DrawFill); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+	// Every row keeps the width of the rectangle's rows, shifted by up to the shear
+	if got, want := sheared.Count(red), d.Count(red); abs(got-want) > 6 {
+		t.Errorf("sheared: got %d pixels, want about %d", got, want)
+	}
+	if err := sheared.ComparePixel(16, 9, red); err != nil {
+		t.Errorf("sheared top-right corner: %v", err)
+	}
+}
+
+// TestParallelogram_String checks the description and the errInvalidShape checks
+func TestParallelogram_String(t *testing.T) {
+	p := NewParallelogram(Point{1, 2}, 3, 4, 5, NewColor("red"))
+	if got, want := p.String(), "Parallelogram: ll=(1,2) w=3 h=4 shear=5"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	for _, bad := range []Parallelogram{
+		NewParallelogram(Point{1, 2}, 0, 4, 0, NewColor("red")),
+		NewParallelogram(Point{1, 2}, 3, -1, 0, NewColor("red")),
+	} {
+		if err := bad.DrawOn(newDisplay(10, 10), DrawFill); err != errInvalidShape {
+			t.Errorf("%v: got %v, want errInvalidShape", bad, err)
+		}
+	}
+}
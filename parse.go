@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Patterns matching the String output of each shape
+// The color part is optional so that bare descriptions such as "Circle: centered around (5,5) with radius 3"
+// are accepted too
+var (
+	colorsPattern    = `(?:,\s*fill\s+(\S+),\s*stroke\s+(\S+))?`
+	pointPattern     = `\(\s*(-?\d+)\s*,\s*(-?\d+)\s*\)`
+	rectanglePattern = regexp.MustCompile(`^Rectangle:\s*` + pointPattern + `\s+to\s+` + pointPattern + colorsPattern + `$`)
+	trianglePattern  = regexp.MustCompile(`^Triangle:\s*` + pointPattern + `,\s*` + pointPattern + `,\s*` + pointPattern + colorsPattern + `$`)
+	circlePattern    = regexp.MustCompile(`^Circle:\s*centered around\s+` + pointPattern + `\s+with radius\s+(-?\d+)` + colorsPattern + `$`)
+)
+
+// parseInts converts regular expression matches to integers
+func parseInts(matches []string) ([]int, error) {
+	values := make([]int, len(matches))
+	for i, m := range matches {
+		v, err := strconv.Atoi(m)
+		if err != nil {
+			return nil, fmt.Errorf("bad number %q: %w", m, errParseShape)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// parseColor converts a color name from a shape description, where "none" is the empty Color
+func parseColor(name string) Color {
+	if name == "none" {
+		return Color{}
+	}
+	return NewColor(name)
+}
+
+// ParseShape converts the output of a shape's String method back into the shape
+// Rectangles, triangles and circles are supported; the shape is returned in its Normalize form
+// Returns errParseShape, wrapped with a description of the problem, for any other input
+func ParseShape(s string) (geometry, error) {
+	s = strings.TrimSpace(s)
+	if m := rectanglePattern.FindStringSubmatch(s); m != nil {
+		v, err := parseInts(m[1:5])
+		if err != nil {
+			return nil, err
+		}
+		return Rectangle{ll: Point{v[0], v[1]}, ur: Point{v[2], v[3]}, c: parseColor(m[5]), stroke: parseColor(m[6]), Thickness: 1}.Normalize(), nil
+	}
+	if m := trianglePattern.FindStringSubmatch(s); m != nil {
+		v, err := parseInts(m[1:7])
+		if err != nil {
+			return nil, err
+		}
+		return Triangle{
+			pt0: Point{v[0], v[1]}, pt1: Point{v[2], v[3]}, pt2: Point{v[4], v[5]},
+			c: parseColor(m[7]), stroke: parseColor(m[8]), Thickness: 1,
+		}.Normalize(), nil
+	}
+	if m := circlePattern.FindStringSubmatch(s); m != nil {
+		v, err := parseInts(m[1:4])
+		if err != nil {
+			return nil, err
+		}
+		return Circle{center: Point{v[0], v[1]}, r: v[2], c: parseColor(m[4]), stroke: parseColor(m[5]), Thickness: 1}.Normalize(), nil
+	}
+
+	name, _, found := strings.Cut(s, ":")
+	if !found {
+		return nil, fmt.Errorf("missing shape name in %q: %w", s, errParseShape)
+	}
+	switch name {
+	case "Rectangle", "Triangle", "Circle":
+		return nil, fmt.Errorf("malformed %s description %q: %w", strings.ToLower(name), s, errParseShape)
+	}
+	return nil, fmt.Errorf("unsupported shape %q: %w", name, errParseShape)
+}
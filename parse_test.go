@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestParseShape_RoundTrip checks that parsing a shape's printShape output gives back an equal shape
+func TestParseShape_RoundTrip(t *testing.T) {
+	for _, s := range []geometry{
+		NewRectangle(Point{0, 0}, Point{10, 10}, NewColor("red")),
+		NewRectangle(Point{-3, 2}, Point{7, 12}, NewColor("blue")).WithStroke(NewColor("black")),
+		NewTriangle(Point{0, 0}, Point{5, 5}, Point{10, 0}, NewColor("green")),
+		NewCircle(Point{5, 5}, 3, NewColor("yellow")).WithStroke(NewColor("red")),
+	} {
+		got, err := ParseShape(s.printShape())
+		if err != nil {
+			t.Errorf("ParseShape(%q): %v", s.printShape(), err)
+			continue
+		}
+		if got != s {
+			t.Errorf("ParseShape(%q) = %+v, want %+v", s.printShape(), got, s)
+		}
+	}
+}
+
+// TestParseShape_BareDescriptions checks the descriptions without colors from the documentation
+func TestParseShape_BareDescriptions(t *testing.T) {
+	for s, want := range map[string]geometry{
+		"Rectangle: (0,0) to (10,10)":                 Rectangle{ur: Point{10, 10}, Thickness: 1},
+		"Triangle: (0,0), (5,5), (10,0)":              Triangle{pt1: Point{5, 5}, pt2: Point{10, 0}, Thickness: 1},
+		"Circle: centered around (5,5) with radius 3": Circle{center: Point{5, 5}, r: 3, Thickness: 1},
+	} {
+		if got, err := ParseShape(s); err != nil || got != want {
+			t.Errorf("ParseShape(%q) = %+v, %v, want %+v", s, got, err, want)
+		}
+	}
+}
+
+// TestParseShape_Malformed checks that malformed input returns errParseShape
+func TestParseShape_Malformed(t *testing.T) {
+	for _, s := range []string{"", "Rectangle: (0,0) to", "Circle: centered around (5,5)", "Hexagon: (0,0)", "no colon"} {
+		if _, err := ParseShape(s); !errors.Is(err, errParseShape) {
+			t.Errorf("ParseShape(%q): got %v, want errParseShape", s, err)
+		}
+	}
+}
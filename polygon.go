@@ -0,0 +1,432 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Polygon struct represents a closed polygon defined by its vertices
+// points: The vertices in order (the last one joins back to the first), c: Fill color
+// Thickness: Outline width in pixels, at least 1
+type Polygon struct {
+	points    []Point // Vertices in order
+	c         Color   // Fill color
+	Thickness int     // Outline width in pixels, at least 1
+}
+
+// NewPolygon creates a Polygon of the given color with the given vertices
+func NewPolygon(c Color, points ...Point) Polygon {
+	return Polygon{points: append([]Point(nil), points...), c: c, Thickness: 1}
+}
+
+// NewPolygonFromHull creates a Polygon of the given color from the convex hull of the points
+// The polygon has no vertices if the points have no hull (fewer than 3 non-collinear points)
+func NewPolygonFromHull(points []Point, c Color) Polygon {
+	return NewPolygon(c, ConvexHull(points)...)
+}
+
+// fillPolygon fills the polygon with the given vertices using an even-odd scanline fill
+// The edges are drawn as well so that the boundary pixels are always colored
+func fillPolygon(scn screen, points []Point, c Color) (err error) {
+	return fillRings(scn, [][]Point{points}, c)
+}
+
+// fillRings fills the area enclosed by the closed rings of vertices using an even-odd scanline
+// fill over the edges of all rings together, so a ring inside another one cuts a hole in it
+// The edges are drawn as well so that the boundary pixels are always colored
+func fillRings(scn screen, rings [][]Point, c Color) (err error) {
+	minY, maxY := rings[0][0].y, rings[0][0].y
+	for _, ring := range rings {
+		for _, p := range ring {
+			minY = min(minY, p.y)
+			maxY = max(maxY, p.y)
+		}
+	}
+
+	// For every scanline collect the x-coordinates where it crosses an edge
+	for y := minY; y <= maxY; y++ {
+		var xs []float64
+		for _, ring := range rings {
+			n := len(ring)
+			for i := 0; i < n; i++ {
+				a, b := ring[i], ring[(i+1)%n]
+				if a.y == b.y {
+					continue
+				}
+				if a.y > b.y {
+					a, b = b, a
+				}
+				// Half-open rule so shared vertices are only counted once
+				if y < a.y || y >= b.y {
+					continue
+				}
+				xs = append(xs, float64(a.x)+float64(y-a.y)*float64(b.x-a.x)/float64(b.y-a.y))
+			}
+		}
+		sort.Float64s(xs)
+
+		// Fill between each pair of crossings
+		for i := 0; i+1 < len(xs); i += 2 {
+			for x := int(math.Ceil(xs[i])); x <= int(math.Floor(xs[i+1])); x++ {
+				if err = scn.drawPixel(x, y, c); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for _, ring := range rings {
+		if err = outlinePolygon(scn, ring, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DrawPolygonWithHole fills the region inside outer but outside inner with the even-odd rule,
+// drawing the edges of both polygons; pixels inside the hole are left unchanged
+// Returns errInvalidShape if either polygon has fewer than 3 vertices or inner does not lie within
+// the bounding box of outer, errOutOfBounds if outer is out of bounds, or invalidColor for an unknown color
+func (d *Display) DrawPolygonWithHole(outer, inner []Point, c Color) error {
+	if len(outer) < 3 || len(inner) < 3 {
+		return errInvalidShape
+	}
+	ob, ib := boxOf(outer), boxOf(inner)
+	if ib.Min.x < ob.Min.x || ib.Min.y < ob.Min.y || ib.Max.x > ob.Max.x || ib.Max.y > ob.Max.y {
+		return errInvalidShape
+	}
+	if anyOutOfBounds(d, outer...) {
+		return errOutOfBounds
+	}
+	if colorUnknown(c) {
+		return invalidColor
+	}
+	return fillRings(d, [][]Point{outer, inner}, c)
+}
+
+// outlinePolygon draws the closed chain of edges through the given vertices
+func outlinePolygon(scn screen, points []Point, c Color) (err error) {
+	for i := range points {
+		if err = drawLine(scn, points[i], points[(i+1)%len(points)], c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DrawOn is the Polygon implementation of the geometry.DrawOn method
+// Draws a filled polygon using scanline filling and/or its edges
+// Returns errInvalidShape if there are fewer than 3 vertices, errSelfIntersecting when filling
+// a self-intersecting polygon, or an error if the polygon is out of bounds or if the color is invalid
+func (pg Polygon) DrawOn(scn screen, mode DrawMode) (err error) {
+	if !mode.valid() {
+		return errInvalidDrawMode
+	}
+	if err = pg.Validate(); err != nil {
+		return err
+	}
+	if anyOutOfBounds(scn, pg.points...) {
+		return errOutOfBounds
+	}
+	if colorUnknown(pg.c) {
+		return invalidColor
+	}
+	size, err := brushSize(pg.Thickness)
+	if err != nil {
+		return err
+	}
+	if mode.fills() {
+		// The scanline fill gives wrong results when edges cross
+		if pg.IsSelfIntersecting() {
+			return errSelfIntersecting
+		}
+		if err = fillPolygon(scn, pg.points, pg.c); err != nil || !mode.outlines() {
+			return err
+		}
+	}
+	return outlinePolygon(withThickness(scn, size), pg.points, pg.c)
+}
+
+// String is the Polygon implementation of the fmt.Stringer interface
+// Returns a string description of the polygon with its number of vertices
+func (pg Polygon) String() string {
+	return fmt.Sprintf("Polygon: %d points", len(pg.points))
+}
+
+// Vertices is the Polygon implementation of the geometry.Vertices method
+// Returns a copy of the polygon's vertices
+func (pg Polygon) Vertices() []Point {
+	return append([]Point(nil), pg.points...)
+}
+
+// Centroid is the Polygon implementation of the geometry.Centroid method
+// Returns the center of mass of the polygon's area, or the average of its vertices
+// if the polygon has no area
+func (pg Polygon) Centroid() Point {
+	a := signedArea(pg.points)
+	if a == 0 {
+		return averagePoint(pg.points)
+	}
+	var cx, cy int
+	for i := range pg.points {
+		p, q := pg.points[i], pg.points[(i+1)%len(pg.points)]
+		z := p.x*q.y - q.x*p.y
+		cx += (p.x + q.x) * z
+		cy += (p.y + q.y) * z
+	}
+	return Point{cx / (3 * a), cy / (3 * a)}
+}
+
+// signedArea returns twice the signed area of the polygon
+// Positive when the vertices run counter-clockwise, negative when clockwise
+func signedArea(points []Point) (area int) {
+	for i := range points {
+		a, b := points[i], points[(i+1)%len(points)]
+		area += a.x*b.y - b.x*a.y
+	}
+	return area
+}
+
+// onSegment returns true if p, known to be collinear with a and b, lies between them
+func onSegment(a, b, p Point) bool {
+	return min(a.x, b.x) <= p.x && p.x <= max(a.x, b.x) && min(a.y, b.y) <= p.y && p.y <= max(a.y, b.y)
+}
+
+// segmentsIntersect returns true if the segments p1-p2 and p3-p4 cross or touch
+func segmentsIntersect(p1, p2, p3, p4 Point) bool {
+	d1, d2 := cross(p3, p4, p1), cross(p3, p4, p2)
+	d3, d4 := cross(p1, p2, p3), cross(p1, p2, p4)
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	return (d1 == 0 && onSegment(p3, p4, p1)) || (d2 == 0 && onSegment(p3, p4, p2)) ||
+		(d3 == 0 && onSegment(p1, p2, p3)) || (d4 == 0 && onSegment(p1, p2, p4))
+}
+
+// insideTriangle returns true if p lies inside the triangle abc or on its edges
+func insideTriangle(p, a, b, c Point) bool {
+	d1, d2, d3 := cross(a, b, p), cross(b, c, p), cross(c, a, p)
+	hasNeg := d1 < 0 || d2 < 0 || d3 < 0
+	hasPos := d1 > 0 || d2 > 0 || d3 > 0
+	return !(hasNeg && hasPos)
+}
+
+// IsSelfIntersecting returns true if any two non-adjacent edges of the polygon cross or touch
+// Uses the naive O(n²) test of every pair of edges
+func (pg Polygon) IsSelfIntersecting() bool {
+	n := len(pg.points)
+	for i := 0; i < n; i++ {
+		for j := i + 2; j < n; j++ {
+			// The first and last edges share a vertex
+			if i == 0 && j == n-1 {
+				continue
+			}
+			if segmentsIntersect(pg.points[i], pg.points[(i+1)%n], pg.points[j], pg.points[(j+1)%n]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsConvex returns true if the polygon turns the same way at every vertex
+// Collinear vertices are ignored; a polygon with fewer than 3 vertices is not convex
+func (pg Polygon) IsConvex() bool {
+	n := len(pg.points)
+	if n < 3 {
+		return false
+	}
+	sign := 0
+	for i := 0; i < n; i++ {
+		z := cross(pg.points[i], pg.points[(i+1)%n], pg.points[(i+2)%n])
+		switch {
+		case z == 0:
+			continue
+		case sign == 0:
+			sign = z
+		case (z > 0) != (sign > 0):
+			return false
+		}
+	}
+	return sign != 0
+}
+
+// Triangulate splits a simple polygon into triangles using the ear-clipping algorithm
+// Works for convex and concave polygons; every triangle gets the polygon's color
+// Returns errInvalidShape for fewer than 3 vertices or a self-intersecting polygon
+func (pg Polygon) Triangulate() ([]Triangle, error) {
+	if len(pg.points) < 3 || pg.IsSelfIntersecting() {
+		return nil, errInvalidShape
+	}
+
+	// Work on indices in counter-clockwise order so that ears turn left
+	idx := make([]int, len(pg.points))
+	for i := range idx {
+		idx[i] = i
+	}
+	if signedArea(pg.points) < 0 {
+		for i, j := 0, len(idx)-1; i < j; i, j = i+1, j-1 {
+			idx[i], idx[j] = idx[j], idx[i]
+		}
+	}
+
+	var triangles []Triangle
+	for len(idx) > 3 {
+		clipped := false
+		for i := range idx {
+			prev := pg.points[idx[(i+len(idx)-1)%len(idx)]]
+			cur := pg.points[idx[i]]
+			next := pg.points[idx[(i+1)%len(idx)]]
+
+			// A reflex vertex or one with another vertex inside is not an ear
+			z := cross(prev, cur, next)
+			if z < 0 || (z > 0 && pg.earBlocked(idx, i, prev, cur, next)) {
+				continue
+			}
+
+			// Collinear vertices are dropped without producing a triangle
+			if z > 0 {
+				triangles = append(triangles, Triangle{pt0: prev, pt1: cur, pt2: next, c: pg.c, Thickness: pg.Thickness})
+			}
+			idx = append(idx[:i], idx[i+1:]...)
+			clipped = true
+			break
+		}
+		if !clipped {
+			return nil, errInvalidShape
+		}
+	}
+	a, b, c := pg.points[idx[0]], pg.points[idx[1]], pg.points[idx[2]]
+	if cross(a, b, c) != 0 {
+		triangles = append(triangles, Triangle{pt0: a, pt1: b, pt2: c, c: pg.c, Thickness: pg.Thickness})
+	}
+	if len(triangles) == 0 {
+		return nil, errInvalidShape
+	}
+	return triangles, nil
+}
+
+// earBlocked returns true if any remaining vertex other than the ear's own three
+// lies inside the candidate ear prev-cur-next at position i of idx
+func (pg Polygon) earBlocked(idx []int, i int, prev, cur, next Point) bool {
+	n := len(idx)
+	for j := range idx {
+		if j == i || j == (i+n-1)%n || j == (i+1)%n {
+			continue
+		}
+		p := pg.points[idx[j]]
+		if p != prev && p != cur && p != next && insideTriangle(p, prev, cur, next) {
+			return true
+		}
+	}
+	return false
+}
+
+// regularPolygonVertices returns the vertices of a regular polygon inscribed in the circle of
+// radius r around (cx,cy), rounded to the nearest pixel and in counter-clockwise order
+// With no rotation the polygon rests on a flat bottom edge; rotationDeg turns it counter-clockwise
+func regularPolygonVertices(cx, cy, r, sides int, rotationDeg float64) []Point {
+	start := (rotationDeg-90)*math.Pi/180 + math.Pi/float64(sides)
+	points := make([]Point, sides)
+	for i := range points {
+		theta := start + 2*math.Pi*float64(i)/float64(sides)
+		points[i] = Point{
+			cx + int(math.Round(float64(r)*math.Cos(theta))),
+			cy + int(math.Round(float64(r)*math.Sin(theta))),
+		}
+	}
+	return points
+}
+
+// regularPolygon validates the parameters of the regular polygon primitives and returns the vertices
+func (d *Display) regularPolygon(cx, cy, r, sides int, rotationDeg float64, c Color) ([]Point, error) {
+	if sides < 3 || r <= 0 {
+		return nil, errInvalidShape
+	}
+	points := regularPolygonVertices(cx, cy, r, sides, rotationDeg)
+	if anyOutOfBounds(d, points...) {
+		return nil, errOutOfBounds
+	}
+	if colorUnknown(c) {
+		return nil, invalidColor
+	}
+	return points, nil
+}
+
+// DrawRegularPolygonOutline draws the edges of the regular polygon with the given number of sides
+// inscribed in the circle of radius r around (cx,cy), turned counter-clockwise by rotationDeg
+// With no rotation the polygon rests on a flat bottom edge, so 4 sides and 45° give a diamond
+// Returns errInvalidShape for sides < 3 or r <= 0, errOutOfBounds if a vertex is outside the
+// display, or invalidColor for an unknown color
+func (d *Display) DrawRegularPolygonOutline(cx, cy, r, sides int, rotationDeg float64, c Color) (err error) {
+	points, err := d.regularPolygon(cx, cy, r, sides, rotationDeg, c)
+	if err != nil {
+		return err
+	}
+	for i, p := range points {
+		q := points[(i+1)%sides]
+		if err = d.DrawLine(p.x, p.y, q.x, q.y, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DrawRegularPolygonFilled fills the regular polygon described by DrawRegularPolygonOutline
+// using a scanline fill
+// Returns the same errors as DrawRegularPolygonOutline
+func (d *Display) DrawRegularPolygonFilled(cx, cy, r, sides int, rotationDeg float64, c Color) (err error) {
+	points, err := d.regularPolygon(cx, cy, r, sides, rotationDeg, c)
+	if err != nil {
+		return err
+	}
+	return fillPolygon(d, points, c)
+}
+
+// starVertices returns the 2*points vertices of a star around (cx,cy), alternating between the
+// outer radius at angles k*2π/points and the inner radius half way between them
+func starVertices(cx, cy, outerR, innerR, points int) []Point {
+	vertices := make([]Point, 0, 2*points)
+	step := 2 * math.Pi / float64(points)
+	for k := 0; k < points; k++ {
+		for i, r := range [2]int{outerR, innerR} {
+			theta := float64(k)*step + float64(i)*step/2
+			vertices = append(vertices, Point{
+				cx + int(math.Round(float64(r)*math.Cos(theta))),
+				cy + int(math.Round(float64(r)*math.Sin(theta))),
+			})
+		}
+	}
+	return vertices
+}
+
+// star returns the Polygon for a star after validating its parameters
+func star(cx, cy, outerR, innerR, points int, c Color) (Polygon, error) {
+	if points < 2 || innerR <= 0 || innerR >= outerR {
+		return Polygon{}, errInvalidShape
+	}
+	return NewPolygon(c, starVertices(cx, cy, outerR, innerR, points)...), nil
+}
+
+// DrawStarPolygon draws a filled star with the given number of points around (cx,cy)
+// The tips lie at outerR and the notches between them at innerR; the first tip points along +x
+// Returns errInvalidShape for points < 2 or innerR not in (0,outerR), errOutOfBounds if a
+// vertex is outside the display, or invalidColor for an unknown color
+func (d *Display) DrawStarPolygon(cx, cy, outerR, innerR, points int, c Color) error {
+	s, err := star(cx, cy, outerR, innerR, points, c)
+	if err != nil {
+		return err
+	}
+	return s.DrawOn(d, DrawBoth)
+}
+
+// DrawStarPolygonOutline draws only the edges of the star described by DrawStarPolygon
+// Returns the same errors as DrawStarPolygon
+func (d *Display) DrawStarPolygonOutline(cx, cy, outerR, innerR, points int, c Color) error {
+	s, err := star(cx, cy, outerR, innerR, points, c)
+	if err != nil {
+		return err
+	}
+	return s.DrawOn(d, DrawOutline)
+}
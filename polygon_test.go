@@ -0,0 +1,256 @@
+package main
+
+import "testing"
+
+// TestConvexHull_KnownPoints checks the hull of 10 points, some of them inside the hull
+// or on its edges, against the 5 expected boundary vertices
+func TestConvexHull_KnownPoints(t *testing.T) {
+	points := []Point{
+		{4, 4}, {0, 0}, {10, 0}, {5, 0}, {12, 6},
+		{3, 7}, {10, 10}, {0, 10}, {6, 5}, {0, 4},
+	}
+	want := []Point{{0, 0}, {10, 0}, {12, 6}, {10, 10}, {0, 10}}
+
+	hull := ConvexHull(points)
+	if len(hull) != len(want) {
+		t.Fatalf("got hull %v, want %v", hull, want)
+	}
+	for i := range want {
+		if hull[i] != want[i] {
+			t.Fatalf("got hull %v, want %v", hull, want)
+		}
+	}
+	for i := range hull {
+		if cross(hull[i], hull[(i+1)%len(hull)], hull[(i+2)%len(hull)]) <= 0 {
+			t.Errorf("hull %v is not strictly counter-clockwise at %v", hull, hull[(i+1)%len(hull)])
+		}
+	}
+}
+
+// TestConvexHull_Degenerate checks that too few or collinear points have no hull
+func TestConvexHull_Degenerate(t *testing.T) {
+	for _, points := range [][]Point{
+		{{1, 1}, {2, 2}},
+		{{0, 0}, {1, 1}, {2, 2}, {3, 3}},
+	} {
+		if hull := ConvexHull(points); hull != nil {
+			t.Errorf("ConvexHull(%v) = %v, want nil", points, hull)
+		}
+	}
+	if pg := NewPolygonFromHull([]Point{{0, 0}, {5, 5}, {9, 9}}, NewColor("red")); len(pg.Vertices()) != 0 {
+		t.Errorf("NewPolygonFromHull of collinear points has vertices %v", pg.Vertices())
+	}
+}
+
+// TestPolygon_TriangulateLShape checks that the ear-clipping triangulation of an L-shaped hexagon
+// gives 4 triangles inside the polygon whose areas add up to the polygon's area, so they
+// cover it without overlapping
+func TestPolygon_TriangulateLShape(t *testing.T) {
+	red := NewColor("red")
+	pg := NewPolygon(red, Point{0, 0}, Point{10, 0}, Point{10, 4}, Point{4, 4}, Point{4, 10}, Point{0, 10})
+	if pg.IsConvex() {
+		t.Error("IsConvex is true for the L shape")
+	}
+
+	triangles, err := pg.Triangulate()
+	if err != nil {
+		t.Fatalf("Triangulate: %v", err)
+	}
+	if len(triangles) != 4 {
+		t.Fatalf("got %d triangles, want 4", len(triangles))
+	}
+	var total float64
+	for _, tri := range triangles {
+		if tri.c != red {
+			t.Errorf("triangle %v does not have the polygon's color", tri)
+		}
+		if !pg.Contains(tri.Centroid()) {
+			t.Errorf("triangle %v lies outside the polygon", tri)
+		}
+		total += shapeArea(tri)
+	}
+	if want := shapeArea(pg); total != want {
+		t.Errorf("triangle areas add up to %v, want %v", total, want)
+	}
+}
+
+// TestPolygon_TriangulateInvalid checks that too few vertices or crossing edges are rejected
+func TestPolygon_TriangulateInvalid(t *testing.T) {
+	red := NewColor("red")
+	for _, pg := range []Polygon{
+		NewPolygon(red, Point{0, 0}, Point{5, 5}),
+		NewPolygon(red, Point{0, 0}, Point{10, 10}, Point{10, 0}, Point{0, 10}),
+	} {
+		if _, err := pg.Triangulate(); err != errInvalidShape {
+			t.Errorf("Triangulate(%v): got %v, want errInvalidShape", pg.Vertices(), err)
+		}
+	}
+	if !NewPolygon(red, Point{0, 0}, Point{10, 0}, Point{10, 10}, Point{0, 10}).IsConvex() {
+		t.Error("IsConvex is false for a square")
+	}
+}
+
+// TestPolygon_IsConvex checks a unit square and an hourglass quadrilateral
+func TestPolygon_IsConvex(t *testing.T) {
+	red := NewColor("red")
+	square := NewPolygon(red, Point{0, 0}, Point{1, 0}, Point{1, 1}, Point{0, 1})
+	if !square.IsConvex() {
+		t.Error("IsConvex is false for the unit square")
+	}
+	hourglass := NewPolygon(red, Point{0, 0}, Point{4, 0}, Point{0, 4}, Point{4, 4})
+	if hourglass.IsConvex() {
+		t.Error("IsConvex is true for the hourglass")
+	}
+	if !hourglass.IsSelfIntersecting() || square.IsSelfIntersecting() {
+		t.Error("IsSelfIntersecting does not tell the hourglass from the square")
+	}
+}
+
+// TestPolygon_BowTieFill checks that filling a bow-tie polygon fails before drawing anything
+func TestPolygon_BowTieFill(t *testing.T) {
+	d := newDisplay(12, 12)
+	bowTie := NewPolygon(NewColor("red"), Point{1, 1}, Point{10, 10}, Point{10, 1}, Point{1, 10})
+	if err := bowTie.DrawOn(d, DrawFill); err != errSelfIntersecting {
+		t.Errorf("got %v, want errSelfIntersecting", err)
+	}
+	if got := d.Count(NewColor("red")); got != 0 {
+		t.Errorf("%d pixels were drawn", got)
+	}
+}
+
+// TestDrawRegularPolygon_Diamond checks that 4 sides turned by 45° give the square with its
+// corners on the axes, drawn the same as the equivalent polygon
+func TestDrawRegularPolygon_Diamond(t *testing.T) {
+	red := NewColor("red")
+	want := []Point{{40, 20}, {20, 40}, {0, 20}, {20, 0}}
+	got := regularPolygonVertices(20, 20, 20, 4, 45)
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("vertex %d is %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	d, square := newDisplay(41, 41), newDisplay(41, 41)
+	if err := d.DrawRegularPolygonFilled(20, 20, 20, 4, 45, red); err != nil {
+		t.Fatalf("DrawRegularPolygonFilled: %v", err)
+	}
+	if err := NewPolygon(red, want...).DrawOn(square, DrawFill); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+	if !d.Equal(square) {
+		t.Error("the diamond differs from the rotated square")
+	}
+}
+
+// TestDrawRegularPolygon_Hexagon checks that 6 sides give six vertices on the circle with equal sides
+func TestDrawRegularPolygon_Hexagon(t *testing.T) {
+	points := regularPolygonVertices(50, 50, 30, 6, 0)
+	if len(points) != 6 {
+		t.Fatalf("got %d vertices, want 6", len(points))
+	}
+	for i, p := range points {
+		if r := p.Distance(Point{50, 50}); r < 29.5 || r > 30.5 {
+			t.Errorf("vertex %v is %.2f from the center, want 30", p, r)
+		}
+		if side := p.Distance(points[(i+1)%6]); side < 29 || side > 31 {
+			t.Errorf("side %d is %.2f long, want 30", i, side)
+		}
+	}
+	d := newDisplay(100, 100)
+	if err := d.DrawRegularPolygonOutline(50, 50, 30, 6, 0, NewColor("red")); err != nil {
+		t.Fatalf("DrawRegularPolygonOutline: %v", err)
+	}
+	for _, p := range points {
+		if err := d.ComparePixel(p.x, p.y, NewColor("red")); err != nil {
+			t.Error(err)
+		}
+	}
+	if err := d.DrawRegularPolygonOutline(50, 50, 30, 2, 0, NewColor("red")); err != errInvalidShape {
+		t.Errorf("2 sides: got %v, want errInvalidShape", err)
+	}
+}
+
+// TestDrawStarPolygon_FivePoints checks a five-pointed star with the golden-ratio proportions of a
+// pentagram (innerR ≈ 0.382 outerR): filled along the tips, empty beyond the notches
+func TestDrawStarPolygon_FivePoints(t *testing.T) {
+	red, white := NewColor("red"), NewColor("white")
+	vertices := starVertices(60, 60, 50, 19, 5)
+	if len(vertices) != 10 {
+		t.Fatalf("got %d vertices, want 10", len(vertices))
+	}
+	d := newDisplay(121, 121)
+	if err := d.DrawStarPolygon(60, 60, 50, 19, 5, red); err != nil {
+		t.Fatalf("DrawStarPolygon: %v", err)
+	}
+	for _, p := range append([]Point{{60, 60}, {95, 60}}, vertices...) {
+		if err := d.ComparePixel(p.x, p.y, red); err != nil {
+			t.Error(err)
+		}
+	}
+	// 35 pixels out along the first notch, at 36°
+	if err := d.ComparePixel(60+28, 60+21, white); err != nil {
+		t.Errorf("beyond the notch: %v", err)
+	}
+
+	outline := newDisplay(121, 121)
+	if err := outline.DrawStarPolygonOutline(60, 60, 50, 19, 5, red); err != nil {
+		t.Fatalf("DrawStarPolygonOutline: %v", err)
+	}
+	if err := outline.ComparePixel(60, 60, white); err != nil {
+		t.Errorf("outline center: %v", err)
+	}
+	if err := d.DrawStarPolygon(60, 60, 19, 50, 5, red); err != errInvalidShape {
+		t.Errorf("innerR > outerR: got %v, want errInvalidShape", err)
+	}
+}
+
+// TestDrawPolygonWithHole_SquareFrame checks that a square with a square hole colors the frame and
+// both outlines but leaves the pixels inside the hole unchanged
+func TestDrawPolygonWithHole_SquareFrame(t *testing.T) {
+	red, blue := NewColor("red"), NewColor("blue")
+	d := newDisplay(20, 20)
+	if err := NewRectangle(Point{8, 8}, Point{12, 12}, blue).DrawOn(d, DrawFill); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+	outer := []Point{{2, 2}, {17, 2}, {17, 17}, {2, 17}}
+	inner := []Point{{7, 7}, {12, 7}, {12, 12}, {7, 12}}
+	if err := d.DrawPolygonWithHole(outer, inner, red); err != nil {
+		t.Fatalf("DrawPolygonWithHole: %v", err)
+	}
+
+	for _, err := range d.AssertRegion(8, 8, 11, 11, blue) {
+		t.Errorf("inside the hole: %v", err)
+	}
+	for _, err := range d.AssertRegion(2, 2, 17, 6, red) {
+		t.Error(err)
+	}
+	for _, err := range d.AssertRegion(7, 7, 12, 7, red) {
+		t.Errorf("edge of the hole: %v", err)
+	}
+	if got := d.Count(red); got != 16*16-4*4 {
+		t.Errorf("got %d red pixels, want %d", got, 16*16-4*4)
+	}
+	if got := d.Count(NewColor("white")); got != 20*20-16*16 {
+		t.Errorf("got %d white pixels, want the %d outside the square", got, 20*20-16*16)
+	}
+
+	if err := d.DrawPolygonWithHole(outer, []Point{{7, 7}, {18, 7}, {7, 12}}, red); err != errInvalidShape {
+		t.Errorf("hole past the outer box: got %v, want errInvalidShape", err)
+	}
+}
+
+// TestPolygon_SingleRingFill checks that a plain square polygon still fills solid, boundary included
+func TestPolygon_SingleRingFill(t *testing.T) {
+	red := NewColor("red")
+	d := newDisplay(20, 20)
+	square := NewPolygon(red, Point{2, 2}, Point{17, 2}, Point{17, 17}, Point{2, 17})
+	if err := square.DrawOn(d, DrawFill); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+	for _, err := range d.AssertRegion(2, 2, 17, 17, red) {
+		t.Error(err)
+	}
+	if got := d.Count(red); got != 16*16 {
+		t.Errorf("got %d red pixels, want %d", got, 16*16)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// colorFromRGB converts an RGB value back to a Color
+// Returns the named color from the ColorMap if one matches exactly, otherwise an inline RGB Color
+func colorFromRGB(rgb RGB) Color {
+	name := ""
+	for n, v := range ColorMap {
+		if v == rgb && (name == "" || n < name) {
+			name = n
+		}
+	}
+	if name != "" {
+		return NewColor(name)
+	}
+	return NewColorRGB(rgb.R, rgb.G, rgb.B)
+}
+
+// sameColor reports whether two colors have the same RGB value
+// Named and inline colors compare equal when they describe the same RGB value
+func sameColor(a, b Color) bool {
+	ra, errA := colorToRGB(a)
+	rb, errB := colorToRGB(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return ra == rb
+}
+
+// ReadPPM loads a P3 PPM image file into a new display
+// Comments starting with '#' are ignored
+// Returns fileError if the file cannot be opened or is not a valid P3 image
+func ReadPPM(filename string) (*Display, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fileError
+	}
+	defer file.Close()
+
+	// Split the file into whitespace separated tokens, dropping comments
+	var tokens []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		tokens = append(tokens, strings.Fields(line)...)
+	}
+	if scanner.Err() != nil || len(tokens) < 4 || tokens[0] != "P3" {
+		return nil, fileError
+	}
+
+	values := make([]int, len(tokens)-1)
+	for i, tok := range tokens[1:] {
+		if values[i], err = strconv.Atoi(tok); err != nil {
+			return nil, fileError
+		}
+	}
+	width, height, maxVal := values[0], values[1], values[2]
+	pixels := values[3:]
+	if width < 0 || height < 0 || maxVal <= 0 || len(pixels) != 3*width*height {
+		return nil, fileError
+	}
+
+	// Pixel data is stored row by row, top to bottom
+	d := newDisplay(width, height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			i := 3 * (y*width + x)
+			d.matrix[x][y] = colorFromRGB(RGB{
+				pixels[i] * 255 / maxVal,
+				pixels[i+1] * 255 / maxVal,
+				pixels[i+2] * 255 / maxVal,
+			})
+		}
+	}
+	return d, nil
+}
+
+// GoldenDir returns the directory holding golden PPM images for snapshot comparisons
+func GoldenDir() string {
+	return filepath.Join("testdata", "golden")
+}
+
+// CompareGolden compares the display against the golden PPM image goldenFile
+// Returns an error naming the first mismatched pixel and its colors, or nil if the images match
+func CompareGolden(d *Display, goldenFile string) error {
+	golden, err := ReadPPM(goldenFile)
+	if err != nil {
+		return fmt.Errorf("golden file %s: %w", goldenFile, err)
+	}
+	if golden.maxX != d.maxX || golden.maxY != d.maxY {
+		return fmt.Errorf("golden file %s is %dx%d, display is %dx%d: %w",
+			goldenFile, golden.maxX, golden.maxY, d.maxX, d.maxY, errDimensionMismatch)
+	}
+	for y := 0; y < d.maxY; y++ {
+		for x := 0; x < d.maxX; x++ {
+			if err = d.ComparePixel(x, y, golden.matrix[x][y]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
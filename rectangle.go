@@ -0,0 +1,216 @@
+package main
+
+// splitRange divides the range [lo,hi) into n parts of equal size
+// The last part also takes the pixels left over by the rounding
+// Returns errInvalidSplit if n < 1 or the range has fewer than n pixels
+func splitRange(lo, hi, n int) ([]int, error) {
+	if n < 1 || hi-lo < n {
+		return nil, errInvalidSplit
+	}
+	size := (hi - lo) / n
+	bounds := make([]int, n+1)
+	for i := range bounds {
+		bounds[i] = lo + i*size
+	}
+	bounds[n] = hi
+	return bounds, nil
+}
+
+// SplitH divides the rectangle into n strips of equal width, from left to right
+// The last strip is wider when the width is not a multiple of n
+// Every strip keeps the rectangle's colors and thickness
+// Returns errInvalidSplit if n < 1 or the rectangle is narrower than n pixels
+func (r Rectangle) SplitH(n int) ([]Rectangle, error) {
+	xs, err := splitRange(r.ll.x, r.ur.x, n)
+	if err != nil {
+		return nil, err
+	}
+	strips := make([]Rectangle, n)
+	for i := range strips {
+		strips[i] = r
+		strips[i].ll.x, strips[i].ur.x = xs[i], xs[i+1]
+	}
+	return strips, nil
+}
+
+// SplitV divides the rectangle into n strips of equal height, from bottom to top
+// The last strip is taller when the height is not a multiple of n
+// Every strip keeps the rectangle's colors and thickness
+// Returns errInvalidSplit if n < 1 or the rectangle is shorter than n pixels
+func (r Rectangle) SplitV(n int) ([]Rectangle, error) {
+	ys, err := splitRange(r.ll.y, r.ur.y, n)
+	if err != nil {
+		return nil, err
+	}
+	strips := make([]Rectangle, n)
+	for i := range strips {
+		strips[i] = r
+		strips[i].ll.y, strips[i].ur.y = ys[i], ys[i+1]
+	}
+	return strips, nil
+}
+
+// SplitGrid divides the rectangle into nx columns and ny rows of equal size
+// The cell in column i and row j is grid[i][j], matching the Display's matrix layout
+// Returns errInvalidSplit if either count is < 1 or larger than the matching side
+func (r Rectangle) SplitGrid(nx, ny int) (grid [][]Rectangle, err error) {
+	columns, err := r.SplitH(nx)
+	if err != nil {
+		return nil, err
+	}
+	grid = make([][]Rectangle, nx)
+	for i, column := range columns {
+		if grid[i], err = column.SplitV(ny); err != nil {
+			return nil, err
+		}
+	}
+	return grid, nil
+}
+
+// roundedCorners validates a rounded rectangle with corners (x0,y0) and (x1,y1)
+// The sides are measured between the corner pixels, so opposite corner circles never cross
+// Returns the corners ordered so that x0 <= x1 and y0 <= y1
+func (d *Display) roundedCorners(x0, y0, x1, y1, radius int, c Color) (lo, hi Point, err error) {
+	lo, hi = Point{min(x0, x1), min(y0, y1)}, Point{max(x0, x1), max(y0, y1)}
+	if radius < 0 || 2*radius > min(hi.x-lo.x, hi.y-lo.y) {
+		return lo, hi, errInvalidCornerRadius
+	}
+	if anyOutOfBounds(d, lo, hi) {
+		return lo, hi, errOutOfBounds
+	}
+	if colorUnknown(c) {
+		return lo, hi, invalidColor
+	}
+	return lo, hi, nil
+}
+
+// DrawRoundedRectangleOutline draws the border of the rectangle with corners (x0,y0) and (x1,y1)
+// inclusive, with each corner replaced by a quarter circle of the given radius
+// The sides are straight lines and the corner pixels come from Bresenham's midpoint circle algorithm
+// Returns errInvalidCornerRadius if the radius is negative or more than half the shorter side,
+// errOutOfBounds if the rectangle does not fit on the display, or invalidColor for an unknown color
+func (d *Display) DrawRoundedRectangleOutline(x0, y0, x1, y1, radius int, c Color) (err error) {
+	lo, hi, err := d.roundedCorners(x0, y0, x1, y1, radius, c)
+	if err != nil {
+		return err
+	}
+	r := radius
+
+	// Straight sides between the corners
+	for _, side := range [][2]Point{
+		{{lo.x + r, lo.y}, {hi.x - r, lo.y}},
+		{{lo.x + r, hi.y}, {hi.x - r, hi.y}},
+		{{lo.x, lo.y + r}, {lo.x, hi.y - r}},
+		{{hi.x, lo.y + r}, {hi.x, hi.y - r}},
+	} {
+		if err = drawLine(d, side[0], side[1], c); err != nil {
+			return err
+		}
+	}
+
+	// Quarter circles around each corner center, one quadrant each
+	corners := [4]struct{ center, sign Point }{
+		{Point{hi.x - r, hi.y - r}, Point{1, 1}},
+		{Point{lo.x + r, hi.y - r}, Point{-1, 1}},
+		{Point{lo.x + r, lo.y + r}, Point{-1, -1}},
+		{Point{hi.x - r, lo.y + r}, Point{1, -1}},
+	}
+	x, y, p := r, 0, 1-r
+	for x >= y {
+		for _, corner := range corners {
+			for _, o := range [2]Point{{x, y}, {y, x}} {
+				px, py := corner.center.x+corner.sign.x*o.x, corner.center.y+corner.sign.y*o.y
+				if err = d.drawPixel(px, py, c); err != nil {
+					return err
+				}
+			}
+		}
+		y++
+		if p <= 0 {
+			p += 2*y + 1
+		} else {
+			x--
+			p += 2*y - 2*x + 1
+		}
+	}
+	return nil
+}
+
+// DrawRoundedRectangleFilled fills the rectangle with corners (x0,y0) and (x1,y1) inclusive,
+// with each corner replaced by a quarter circle of the given radius
+// Returns the same errors as DrawRoundedRectangleOutline
+func (d *Display) DrawRoundedRectangleFilled(x0, y0, x1, y1, radius int, c Color) (err error) {
+	lo, hi, err := d.roundedCorners(x0, y0, x1, y1, radius, c)
+	if err != nil {
+		return err
+	}
+	r := radius
+	for x := lo.x; x <= hi.x; x++ {
+		for y := lo.y; y <= hi.y; y++ {
+			// Pixels in a corner square must lie within half a pixel of that corner's circle,
+			// which covers every pixel the midpoint algorithm draws for the outline
+			center := Point{max(lo.x+r, min(x, hi.x-r)), max(lo.y+r, min(y, hi.y-r))}
+			if !insideCircle(center, Point{x, y}, float64(r)+0.5) {
+				continue
+			}
+			if err = d.drawPixel(x, y, c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Intersect returns the area covered by both rectangles, with the receiver's colors and thickness
+// Returns false if the rectangles do not share any pixel
+func (r Rectangle) Intersect(other Rectangle) (Rectangle, bool) {
+	out := r
+	out.ll = Point{max(r.ll.x, other.ll.x), max(r.ll.y, other.ll.y)}
+	out.ur = Point{min(r.ur.x, other.ur.x), min(r.ur.y, other.ur.y)}
+	if out.ll.x >= out.ur.x || out.ll.y >= out.ur.y {
+		return Rectangle{}, false
+	}
+	return out, true
+}
+
+// Union returns the smallest rectangle covering both rectangles, with the receiver's colors and thickness
+func (r Rectangle) Union(other Rectangle) Rectangle {
+	out := r
+	out.ll = Point{min(r.ll.x, other.ll.x), min(r.ll.y, other.ll.y)}
+	out.ur = Point{max(r.ur.x, other.ur.x), max(r.ur.y, other.ur.y)}
+	return out
+}
+
+// Subtract returns the parts of the rectangle not covered by the other one as up to 4 rectangles:
+// the full-width strips below and above the overlap, then the pieces left and right of it
+// The pieces keep the receiver's colors and thickness; without an overlap the rectangle is returned whole
+func (r Rectangle) Subtract(other Rectangle) []Rectangle {
+	in, ok := r.Intersect(other)
+	if !ok {
+		return []Rectangle{r}
+	}
+	var pieces []Rectangle
+	add := func(ll, ur Point) {
+		if ll.x < ur.x && ll.y < ur.y {
+			piece := r
+			piece.ll, piece.ur = ll, ur
+			pieces = append(pieces, piece)
+		}
+	}
+	add(r.ll, Point{r.ur.x, in.ll.y})
+	add(Point{r.ll.x, in.ur.y}, r.ur)
+	add(Point{r.ll.x, in.ll.y}, Point{in.ll.x, in.ur.y})
+	add(Point{in.ur.x, in.ll.y}, Point{r.ur.x, in.ur.y})
+	return pieces
+}
+
+// DrawRect draws the outline of the w x h rectangle with its corner at (x,y)
+// Returns the same errors as drawing Rectangle{ll: (x,y), ur: (x+w,y+h), Thickness: 1} in DrawOutline mode
+func (d *Display) DrawRect(x, y, w, h int, c Color) error {
+	return Rectangle{ll: Point{x, y}, ur: Point{x + w, y + h}, c: c, Thickness: 1}.DrawOn(d, DrawOutline)
+}
+
+// FillRect is DrawRect filling the rectangle instead of outlining it
+func (d *Display) FillRect(x, y, w, h int, c Color) error {
+	return Rectangle{ll: Point{x, y}, ur: Point{x + w, y + h}, c: c, Thickness: 1}.DrawOn(d, DrawFill)
+}
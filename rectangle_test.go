@@ -0,0 +1,189 @@
+package main
+
+import "testing"
+
+// TestRectangle_FillAndStroke checks that a rectangle with a red fill and a blue stroke has
+// red interior pixels and blue border pixels
+func TestRectangle_FillAndStroke(t *testing.T) {
+	red, blue := NewColor("red"), NewColor("blue")
+	r := NewRectangle(Point{2, 2}, Point{9, 7}, red).WithStroke(blue)
+	d := newDisplay(12, 10)
+	if err := r.DrawOn(d, DrawDefault); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+
+	var errs []error
+	errs = append(errs, d.AssertRegion(3, 3, 7, 5, red)...)
+	errs = append(errs, d.AssertRegion(2, 2, 8, 2, blue)...)
+	errs = append(errs, d.AssertRegion(2, 6, 8, 6, blue)...)
+	errs = append(errs, d.AssertRegion(2, 2, 2, 6, blue)...)
+	errs = append(errs, d.AssertRegion(8, 2, 8, 6, blue)...)
+	for _, err := range errs {
+		t.Error(err)
+	}
+}
+
+// TestRectangle_StringColors checks that the description names both the fill and the stroke
+func TestRectangle_StringColors(t *testing.T) {
+	r := NewRectangle(Point{2, 2}, Point{9, 7}, NewColor("red")).WithStroke(NewColor("blue"))
+	if got, want := r.String(), "Rectangle: (2,2) to (9,7), fill red, stroke blue"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+// TestRectangle_ZeroThickness checks that a rectangle literal without a thickness is rejected
+func TestRectangle_ZeroThickness(t *testing.T) {
+	r := Rectangle{ll: Point{1, 1}, ur: Point{8, 8}, c: NewColor("red")}
+	if err := r.DrawOn(newDisplay(10, 10), DrawOutline); err != errInvalidThickness {
+		t.Errorf("got %v, want errInvalidThickness", err)
+	}
+}
+
+// TestRectangle_SplitGrid checks that a 100x100 rectangle split 4x4 gives 16 cells of 25x25 in its color
+func TestRectangle_SplitGrid(t *testing.T) {
+	r := NewRectangle(Point{0, 0}, Point{100, 100}, NewColor("red"))
+	grid, err := r.SplitGrid(4, 4)
+	if err != nil {
+		t.Fatalf("SplitGrid: %v", err)
+	}
+	cells := 0
+	for i, column := range grid {
+		for j, cell := range column {
+			cells++
+			if want := NewRectangle(Point{25 * i, 25 * j}, Point{25*i + 25, 25*j + 25}, r.c); cell != want {
+				t.Errorf("cell (%d,%d) is %v, want %v", i, j, cell, want)
+			}
+		}
+	}
+	if cells != 16 {
+		t.Errorf("got %d cells, want 16", cells)
+	}
+}
+
+// TestRectangle_SplitUneven checks that the last strip takes the leftover pixels and that bad counts are rejected
+func TestRectangle_SplitUneven(t *testing.T) {
+	r := NewRectangle(Point{0, 0}, Point{10, 7}, NewColor("red"))
+	strips, err := r.SplitV(3)
+	if err != nil {
+		t.Fatalf("SplitV: %v", err)
+	}
+	if got := strips[2]; got.ll.y != 4 || got.ur.y != 7 {
+		t.Errorf("last strip covers y %d..%d, want 4..7", got.ll.y, got.ur.y)
+	}
+	for _, n := range []int{0, 11} {
+		if _, err := r.SplitH(n); err != errInvalidSplit {
+			t.Errorf("SplitH(%d): got %v, want errInvalidSplit", n, err)
+		}
+	}
+}
+
+// TestDrawRoundedRectangleOutline_NoInterior checks that the outline colors no interior pixels,
+// stays inside the filled shape and leaves the rounded-off corners alone
+func TestDrawRoundedRectangleOutline_NoInterior(t *testing.T) {
+	red := NewColor("red")
+	outline, filled := newDisplay(40, 30), newDisplay(40, 30)
+	if err := outline.DrawRoundedRectangleOutline(5, 5, 34, 24, 5, red); err != nil {
+		t.Fatalf("DrawRoundedRectangleOutline: %v", err)
+	}
+	if err := filled.DrawRoundedRectangleFilled(5, 5, 34, 24, 5, red); err != nil {
+		t.Fatalf("DrawRoundedRectangleFilled: %v", err)
+	}
+
+	// The two bands crossing the middle of the shape only touch the straight sides
+	if n := outline.CountInRegion(red, 6, 10, 33, 19) + outline.CountInRegion(red, 10, 6, 29, 23); n != 0 {
+		t.Errorf("the outline colors %d interior pixels", n)
+	}
+	outline.PixelScan(func(x, y int, c Color) {
+		if c == red && filled.matrix[x][y] != red {
+			t.Errorf("outline pixel (%d,%d) is outside the filled shape", x, y)
+		}
+	})
+	for _, p := range []Point{{5, 5}, {34, 24}} {
+		if err := filled.ComparePixel(p.x, p.y, NewColor("white")); err != nil {
+			t.Errorf("rounded corner: %v", err)
+		}
+	}
+	if err := outline.DrawRoundedRectangleOutline(5, 5, 34, 24, 11, red); err != errInvalidCornerRadius {
+		t.Errorf("radius 11: got %v, want errInvalidCornerRadius", err)
+	}
+}
+
+// TestRectangle_Intersect checks identical, partially overlapping, touching and disjoint rectangles
+func TestRectangle_Intersect(t *testing.T) {
+	red := NewColor("red")
+	r := NewRectangle(Point{0, 0}, Point{10, 10}, red)
+	for _, tc := range []struct {
+		name  string
+		other Rectangle
+		want  Rectangle
+		ok    bool
+	}{
+		{"identical", r, r, true},
+		{"partial", NewRectangle(Point{5, 6}, Point{15, 20}, red), NewRectangle(Point{5, 6}, Point{10, 10}, red), true},
+		{"touching", NewRectangle(Point{10, 0}, Point{20, 10}, red), Rectangle{}, false},
+		{"disjoint", NewRectangle(Point{30, 30}, Point{40, 40}, red), Rectangle{}, false},
+	} {
+		got, ok := r.Intersect(tc.other)
+		if ok != tc.ok || got != tc.want {
+			t.Errorf("%s: got %v, %v, want %v, %v", tc.name, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+// TestRectangle_UnionAndSubtract checks the bounding box of two rectangles and the pieces left
+// after cutting a hole out of the middle of a rectangle
+func TestRectangle_UnionAndSubtract(t *testing.T) {
+	red := NewColor("red")
+	r := NewRectangle(Point{0, 0}, Point{10, 10}, red)
+	if got, want := r.Union(NewRectangle(Point{20, 5}, Point{30, 25}, red)), NewRectangle(Point{0, 0}, Point{30, 25}, red); got != want {
+		t.Errorf("Union: got %v, want %v", got, want)
+	}
+
+	pieces := r.Subtract(NewRectangle(Point{3, 4}, Point{6, 8}, red))
+	if len(pieces) != 4 {
+		t.Fatalf("got %d pieces, want 4", len(pieces))
+	}
+	d := newDisplay(11, 11) // ur is exclusive but must still lie on the display
+	area := 0
+	for _, p := range pieces {
+		area += (p.ur.x - p.ll.x) * (p.ur.y - p.ll.y)
+		if err := p.DrawOn(d, DrawFill); err != nil {
+			t.Fatalf("DrawOn: %v", err)
+		}
+	}
+	if area != 100-12 || d.Count(red) != 100-12 {
+		t.Errorf("pieces cover %d pixels (%d drawn), want %d without overlaps", area, d.Count(red), 100-12)
+	}
+	for _, err := range d.AssertRegion(3, 4, 5, 7, NewColor("white")) {
+		t.Errorf("hole: %v", err)
+	}
+}
+
+// TestFillRect_WidthHeight checks that FillRect covers exactly w x h pixels from (x,y) and that
+// DrawRect outlines the same rectangle
+func TestFillRect_WidthHeight(t *testing.T) {
+	red := NewColor("red")
+	d := newDisplay(20, 20)
+	if err := d.FillRect(3, 4, 5, 6, red); err != nil {
+		t.Fatalf("FillRect: %v", err)
+	}
+	for _, err := range d.AssertRegion(3, 4, 7, 9, red) {
+		t.Error(err)
+	}
+	if got := d.Count(red); got != 5*6 {
+		t.Errorf("FillRect colored %d pixels, want 30", got)
+	}
+
+	d = newDisplay(20, 20)
+	if err := d.DrawRect(3, 4, 5, 6, red); err != nil {
+		t.Fatalf("DrawRect: %v", err)
+	}
+	for _, p := range []Point{{3, 4}, {7, 4}, {3, 9}, {7, 9}} {
+		if err := d.ComparePixel(p.x, p.y, red); err != nil {
+			t.Errorf("corner: %v", err)
+		}
+	}
+	for _, err := range d.AssertRegion(4, 5, 6, 8, NewColor("white")) {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// Scene struct holds shapes on named layers that are drawn in a fixed order
+// layers: Shapes on each layer in drawing order, order: Layer names from bottom to top
+type Scene struct {
+	layers map[string][]geometry // Shapes on each layer
+	order  []string              // Layer names, bottom layer first
+}
+
+// NewScene creates a scene with a single empty "default" layer
+func NewScene() *Scene {
+	s := &Scene{layers: map[string][]geometry{}}
+	s.AddLayer("default")
+	return s
+}
+
+// AddLayer adds an empty layer on top of the existing ones
+// Adding a layer that already exists does nothing
+func (s *Scene) AddLayer(name string) {
+	if _, exists := s.layers[name]; exists {
+		return
+	}
+	s.layers[name] = nil
+	s.order = append(s.order, name)
+}
+
+// DrawOnLayer adds a shape to the top of the named layer
+// The layer is added on top of the scene if it does not exist yet
+func (s *Scene) DrawOnLayer(name string, g geometry) {
+	s.AddLayer(name)
+	s.layers[name] = append(s.layers[name], g)
+}
+
+// RemoveLayer removes the named layer and all of its shapes
+// Returns errUnknownLayer if there is no such layer
+func (s *Scene) RemoveLayer(name string) error {
+	if _, exists := s.layers[name]; !exists {
+		return errUnknownLayer
+	}
+	delete(s.layers, name)
+	for i, n := range s.order {
+		if n == name {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// SetLayerOrder changes the drawing order of the layers, bottom layer first
+// Returns errUnknownLayer if a name is not a layer, or errInvalidLayerOrder if
+// the names do not list every layer exactly once
+func (s *Scene) SetLayerOrder(names []string) error {
+	seen := map[string]bool{}
+	for _, name := range names {
+		if _, exists := s.layers[name]; !exists {
+			return errUnknownLayer
+		}
+		if seen[name] {
+			return errInvalidLayerOrder
+		}
+		seen[name] = true
+	}
+	if len(names) != len(s.layers) {
+		return errInvalidLayerOrder
+	}
+	s.order = append([]string(nil), names...)
+	return nil
+}
+
+// Render draws every layer onto the display from the bottom layer to the top,
+// so shapes on higher layers overwrite those below
+// Every shape is drawn even if earlier ones fail; returns the errors of the shapes that failed
+func (s *Scene) Render(d *Display) (errs []error) {
+	for _, name := range s.order {
+		for _, g := range s.layers[name] {
+			if err := g.DrawOn(d, DrawDefault); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errs
+}
+
+// sceneJSON is the JSON form of a Scene
+// Each shape is stored as its String description
+type sceneJSON struct {
+	Order  []string            `json:"order"`
+	Layers map[string][]string `json:"layers"`
+}
+
+// MarshalJSON encodes the scene with its layer order and the description of every shape
+func (s *Scene) MarshalJSON() ([]byte, error) {
+	out := sceneJSON{Order: s.order, Layers: map[string][]string{}}
+	for name, shapes := range s.layers {
+		descriptions := []string{}
+		for _, g := range shapes {
+			descriptions = append(descriptions, g.String())
+		}
+		out.Layers[name] = descriptions
+	}
+	return json.Marshal(out)
+}
+
+// zShape is a shape queued on a display by DrawWithZ
+type zShape struct {
+	g geometry // Shape to draw
+	z int      // Stacking order, higher is drawn on top
+}
+
+// DrawWithZ queues the shape to be drawn by Commit at stacking order z
+// Shapes with a higher z are drawn on top; shapes with the same z are drawn in the order they were queued
+// Nothing is drawn until Commit is called
+// Returns the shape's Validate error, without queuing it, if the shape is degenerate
+func (d *Display) DrawWithZ(g geometry, z int) error {
+	if err := g.Validate(); err != nil {
+		return err
+	}
+	i := sort.Search(len(d.pending), func(i int) bool { return d.pending[i].z > z })
+	d.pending = append(d.pending, zShape{})
+	copy(d.pending[i+1:], d.pending[i:])
+	d.pending[i] = zShape{g, z}
+	return nil
+}
+
+// Commit draws the queued shapes from the lowest z to the highest and empties the queue
+// Every shape is drawn even if earlier ones fail; returns the errors of the shapes that failed
+func (d *Display) Commit() (errs []error) {
+	pending := d.pending
+	d.pending = nil
+	for _, zs := range pending {
+		if err := zs.g.DrawOn(d, DrawDefault); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// ClearPending discards the shapes queued by DrawWithZ without drawing them
+func (d *Display) ClearPending() {
+	d.pending = nil
+}
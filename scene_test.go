@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestScene_HigherLayerOnTop checks that shapes on a higher layer overwrite lower-layer pixels,
+// whichever layer they were added to first, and that reordering the layers swaps them
+func TestScene_HigherLayerOnTop(t *testing.T) {
+	red, blue := NewColor("red"), NewColor("blue")
+	s := NewScene()
+	s.AddLayer("top")
+	s.DrawOnLayer("top", NewRectangle(Point{5, 5}, Point{15, 15}, blue))
+	s.DrawOnLayer("default", NewRectangle(Point{0, 0}, Point{10, 10}, red))
+
+	d := newDisplay(20, 20)
+	if errs := s.Render(d); len(errs) != 0 {
+		t.Fatalf("Render: %v", errs)
+	}
+	if err := d.ComparePixel(7, 7, blue); err != nil {
+		t.Errorf("overlap: %v", err)
+	}
+	if err := d.ComparePixel(2, 2, red); err != nil {
+		t.Error(err)
+	}
+
+	if err := s.SetLayerOrder([]string{"top", "default"}); err != nil {
+		t.Fatalf("SetLayerOrder: %v", err)
+	}
+	d = newDisplay(20, 20)
+	if errs := s.Render(d); len(errs) != 0 {
+		t.Fatalf("Render: %v", errs)
+	}
+	if err := d.ComparePixel(7, 7, red); err != nil {
+		t.Errorf("overlap after reordering: %v", err)
+	}
+}
+
+// TestScene_LayerErrors checks removing an unknown layer and invalid layer orders
+func TestScene_LayerErrors(t *testing.T) {
+	s := NewScene()
+	s.AddLayer("top")
+	if err := s.RemoveLayer("missing"); err != errUnknownLayer {
+		t.Errorf("RemoveLayer: got %v, want errUnknownLayer", err)
+	}
+	if err := s.SetLayerOrder([]string{"top"}); err != errInvalidLayerOrder {
+		t.Errorf("missing layer: got %v, want errInvalidLayerOrder", err)
+	}
+	if err := s.SetLayerOrder([]string{"top", "top"}); err != errInvalidLayerOrder {
+		t.Errorf("repeated layer: got %v, want errInvalidLayerOrder", err)
+	}
+	if err := s.RemoveLayer("top"); err != nil {
+		t.Errorf("RemoveLayer: %v", err)
+	}
+}
+
+// TestScene_MarshalJSON checks the JSON form of a scene
+func TestScene_MarshalJSON(t *testing.T) {
+	s := NewScene()
+	s.DrawOnLayer("default", NewPolyline(NewColor("red"), Point{1, 1}, Point{2, 2}))
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if got, want := string(data), `{"order":["default"],"layers":{"default":["Polyline: 2 points"]}}`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+// TestDrawWithZ_CircleOnTop checks that a z=1 circle ends up over a z=0 rectangle whichever is queued
+// first, and that ClearPending drops the queue
+func TestDrawWithZ_CircleOnTop(t *testing.T) {
+	red, blue := NewColor("red"), NewColor("blue")
+	rect := NewRectangle(Point{2, 2}, Point{18, 18}, red)
+	circle := NewCircle(Point{10, 10}, 5, blue)
+	want := newDisplay(20, 20)
+	if err := rect.DrawOn(want, DrawFill); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+	if err := circle.DrawOn(want, DrawFill); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+
+	for _, order := range [][]zShape{{{rect, 0}, {circle, 1}}, {{circle, 1}, {rect, 0}}} {
+		d := newDisplay(20, 20)
+		for _, zs := range order {
+			if err := d.DrawWithZ(zs.g, zs.z); err != nil {
+				t.Fatalf("DrawWithZ: %v", err)
+			}
+		}
+		if errs := d.Commit(); errs != nil {
+			t.Fatalf("Commit: %v", errs)
+		}
+		if !d.Equal(want) {
+			t.Errorf("queued %T first: the circle is not on top", order[0].g)
+		}
+		if err := d.ComparePixel(10, 10, blue); err != nil {
+			t.Error(err)
+		}
+	}
+
+	d := newDisplay(20, 20)
+	if err := d.DrawWithZ(circle, 0); err != nil {
+		t.Fatalf("DrawWithZ: %v", err)
+	}
+	d.ClearPending()
+	d.Commit()
+	if d.Count(blue) != 0 {
+		t.Error("ClearPending left the circle queued")
+	}
+}
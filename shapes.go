@@ -0,0 +1,542 @@
+package main
+
+import (
+	"math"
+	"sort"
+)
+
+// averagePoint returns the average of the points, or the origin if there are none
+func averagePoint(points []Point) Point {
+	if len(points) == 0 {
+		return Point{}
+	}
+	var sx, sy int
+	for _, p := range points {
+		sx += p.x
+		sy += p.y
+	}
+	return Point{sx / len(points), sy / len(points)}
+}
+
+// shapeArea returns the area covered by a shape
+// Lines and polylines have no area
+func shapeArea(g geometry) float64 {
+	switch s := g.(type) {
+	case Rectangle:
+		return math.Abs(float64((s.ur.x - s.ll.x) * (s.ur.y - s.ll.y)))
+	case Triangle:
+		return math.Abs(float64(cross(s.pt0, s.pt1, s.pt2))) / 2
+	case Circle:
+		return math.Pi * float64(s.r) * float64(s.r)
+	case Polygon:
+		return math.Abs(float64(signedArea(s.points))) / 2
+	case Parallelogram:
+		return float64(s.width * s.height)
+	}
+	return 0
+}
+
+// CentroidOf returns the average of the shapes' centroids weighted by their areas
+// If none of the shapes has an area, the plain average of the centroids is returned
+func CentroidOf(shapes []geometry) Point {
+	var sx, sy, total float64
+	centroids := make([]Point, 0, len(shapes))
+	for _, g := range shapes {
+		c, a := g.Centroid(), shapeArea(g)
+		centroids = append(centroids, c)
+		sx += float64(c.x) * a
+		sy += float64(c.y) * a
+		total += a
+	}
+	if total == 0 {
+		return averagePoint(centroids)
+	}
+	return Point{int(math.Round(sx / total)), int(math.Round(sy / total))}
+}
+
+// Box struct represents an axis-aligned box of pixels
+// Min, Max: The lowest and highest corner, both inclusive
+type Box struct {
+	Min Point // Lowest x and y covered
+	Max Point // Highest x and y covered
+}
+
+// boxOf returns the smallest box containing all of the points
+func boxOf(points []Point) Box {
+	if len(points) == 0 {
+		return Box{}
+	}
+	b := Box{points[0], points[0]}
+	for _, p := range points[1:] {
+		b.Min = Point{min(b.Min.x, p.x), min(b.Min.y, p.y)}
+		b.Max = Point{max(b.Max.x, p.x), max(b.Max.y, p.y)}
+	}
+	return b
+}
+
+// Intersects returns true if the two boxes share at least one pixel
+func (b Box) Intersects(o Box) bool {
+	return b.Min.x <= o.Max.x && o.Min.x <= b.Max.x && b.Min.y <= o.Max.y && o.Min.y <= b.Max.y
+}
+
+// BoundingBox is the Rectangle implementation of the geometry.BoundingBox method
+// Covers the pixels filled by DrawOn, whose upper bounds are exclusive
+func (r Rectangle) BoundingBox() Box {
+	return Box{
+		Point{min(r.ll.x, r.ur.x), min(r.ll.y, r.ur.y)},
+		Point{max(r.ll.x, r.ur.x) - 1, max(r.ll.y, r.ur.y) - 1},
+	}
+}
+
+// BoundingBox is the Triangle implementation of the geometry.BoundingBox method
+func (t Triangle) BoundingBox() Box {
+	return boxOf(t.Vertices())
+}
+
+// BoundingBox is the Circle implementation of the geometry.BoundingBox method
+func (c Circle) BoundingBox() Box {
+	return Box{Point{c.center.x - c.r, c.center.y - c.r}, Point{c.center.x + c.r, c.center.y + c.r}}
+}
+
+// BoundingBox is the Line implementation of the geometry.BoundingBox method
+func (l Line) BoundingBox() Box {
+	return boxOf(l.Vertices())
+}
+
+// BoundingBox is the Polyline implementation of the geometry.BoundingBox method
+func (pl Polyline) BoundingBox() Box {
+	return boxOf(pl.points)
+}
+
+// BoundingBox is the Polygon implementation of the geometry.BoundingBox method
+func (pg Polygon) BoundingBox() Box {
+	return boxOf(pg.points)
+}
+
+// Contains is the Rectangle implementation of the geometry.Contains method
+// Returns true for the pixels filled by DrawOn, whose upper bounds are exclusive
+func (r Rectangle) Contains(p Point) bool {
+	b := r.BoundingBox()
+	return p.x >= b.Min.x && p.x <= b.Max.x && p.y >= b.Min.y && p.y <= b.Max.y
+}
+
+// Contains is the Triangle implementation of the geometry.Contains method
+// Solves p = pt0 + u*(pt2-pt0) + v*(pt1-pt0) for the barycentric coordinates u and v with dot
+// products; p is inside when u >= 0, v >= 0 and u+v <= 1
+// The comparisons are done on u and v scaled by the (integer) denominator, so points on the
+// edges and vertices are exact; a degenerate triangle contains only the points on its edges
+func (t Triangle) Contains(p Point) bool {
+	v0 := Point{t.pt2.x - t.pt0.x, t.pt2.y - t.pt0.y}
+	v1 := Point{t.pt1.x - t.pt0.x, t.pt1.y - t.pt0.y}
+	v2 := Point{p.x - t.pt0.x, p.y - t.pt0.y}
+	dot := func(a, b Point) int { return a.x*b.x + a.y*b.y }
+	d00, d01, d02, d11, d12 := dot(v0, v0), dot(v0, v1), dot(v0, v2), dot(v1, v1), dot(v1, v2)
+
+	den := d00*d11 - d01*d01
+	if den == 0 {
+		return Polygon{points: t.Vertices()}.Contains(p)
+	}
+	u := d11*d02 - d01*d12
+	v := d00*d12 - d01*d02
+	return u >= 0 && v >= 0 && u+v <= den
+}
+
+// Contains is the Circle implementation of the geometry.Contains method
+// Returns true for the pixels filled by DrawOn
+func (c Circle) Contains(p Point) bool {
+	return insideCircle(c.center, p, float64(c.r))
+}
+
+// Contains is the Line implementation of the geometry.Contains method
+// Returns true if the point lies exactly on the segment
+func (l Line) Contains(p Point) bool {
+	return cross(l.p0, l.p1, p) == 0 && onSegment(l.p0, l.p1, p)
+}
+
+// Contains is the Polyline implementation of the geometry.Contains method
+// Returns true if the point lies exactly on one of the segments
+func (pl Polyline) Contains(p Point) bool {
+	for i := 1; i < len(pl.points); i++ {
+		if (Line{p0: pl.points[i-1], p1: pl.points[i]}).Contains(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains is the Polygon implementation of the geometry.Contains method
+// Uses the even-odd rule; points on an edge are inside
+func (pg Polygon) Contains(p Point) bool {
+	inside := false
+	n := len(pg.points)
+	for i := 0; i < n; i++ {
+		a, b := pg.points[i], pg.points[(i+1)%n]
+		if cross(a, b, p) == 0 && onSegment(a, b, p) {
+			return true
+		}
+		if (a.y > p.y) != (b.y > p.y) &&
+			float64(p.x) < float64(a.x)+float64(p.y-a.y)*float64(b.x-a.x)/float64(b.y-a.y) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// Validate is the Rectangle implementation of the geometry.Validate method
+// Returns errInvalidShape if the rectangle has no width or height
+func (r Rectangle) Validate() error {
+	if r.ll.x >= r.ur.x || r.ll.y >= r.ur.y {
+		return errInvalidShape
+	}
+	return nil
+}
+
+// Validate is the Triangle implementation of the geometry.Validate method
+// Returns errInvalidShape if the three vertices are collinear
+func (t Triangle) Validate() error {
+	if cross(t.pt0, t.pt1, t.pt2) == 0 {
+		return errInvalidShape
+	}
+	return nil
+}
+
+// Validate is the Circle implementation of the geometry.Validate method
+// Returns errInvalidShape if the radius is not positive
+func (c Circle) Validate() error {
+	if c.r <= 0 {
+		return errInvalidShape
+	}
+	return nil
+}
+
+// Validate is the Line implementation of the geometry.Validate method
+// Any two end points make a valid line, so it always returns nil
+func (l Line) Validate() error {
+	return nil
+}
+
+// Validate is the Polyline implementation of the geometry.Validate method
+// Returns errInvalidShape if there are fewer than 2 points
+func (pl Polyline) Validate() error {
+	if len(pl.points) < 2 {
+		return errInvalidShape
+	}
+	return nil
+}
+
+// Validate is the Polygon implementation of the geometry.Validate method
+// Returns errInvalidShape if there are fewer than 3 vertices
+func (pg Polygon) Validate() error {
+	if len(pg.points) < 3 {
+		return errInvalidShape
+	}
+	return nil
+}
+
+// pointLess orders points by x and then by y
+func pointLess(a, b Point) bool {
+	return a.x < b.x || (a.x == b.x && a.y < b.y)
+}
+
+// Normalize is the Rectangle implementation of the geometry.Normalize method
+// Swaps the corner coordinates where needed so that ll.x <= ur.x and ll.y <= ur.y
+func (r Rectangle) Normalize() geometry {
+	r.ll, r.ur = Point{min(r.ll.x, r.ur.x), min(r.ll.y, r.ur.y)}, Point{max(r.ll.x, r.ur.x), max(r.ll.y, r.ur.y)}
+	return r
+}
+
+// Normalize is the Triangle implementation of the geometry.Normalize method
+// Sorts the vertices by x and then by y
+func (t Triangle) Normalize() geometry {
+	pts := []Point{t.pt0, t.pt1, t.pt2}
+	sort.Slice(pts, func(i, j int) bool { return pointLess(pts[i], pts[j]) })
+	t.pt0, t.pt1, t.pt2 = pts[0], pts[1], pts[2]
+	return t
+}
+
+// Normalize is the Circle implementation of the geometry.Normalize method
+// Makes a negative radius positive
+func (c Circle) Normalize() geometry {
+	c.r = abs(c.r)
+	return c
+}
+
+// Normalize is the Line implementation of the geometry.Normalize method
+// Orders the end points so that p0 comes before p1 by x and then by y
+func (l Line) Normalize() geometry {
+	if pointLess(l.p1, l.p0) {
+		l.p0, l.p1 = l.p1, l.p0
+	}
+	return l
+}
+
+// Normalize is the Polyline implementation of the geometry.Normalize method
+// Reverses a copy of the points if the last point comes before the first by x and then by y
+func (pl Polyline) Normalize() geometry {
+	pl.points = pl.Vertices()
+	if n := len(pl.points); n > 1 && pointLess(pl.points[n-1], pl.points[0]) {
+		for i, j := 0, n-1; i < j; i, j = i+1, j-1 {
+			pl.points[i], pl.points[j] = pl.points[j], pl.points[i]
+		}
+	}
+	return pl
+}
+
+// Normalize is the Polygon implementation of the geometry.Normalize method
+// Rotates a copy of the vertices to start at the first one by x and then by y,
+// keeping their order around the polygon
+func (pg Polygon) Normalize() geometry {
+	first := 0
+	for i, p := range pg.points {
+		if pointLess(p, pg.points[first]) {
+			first = i
+		}
+	}
+	pg.points = append(append([]Point(nil), pg.points[first:]...), pg.points[:first]...)
+	return pg
+}
+
+// offsetPoints returns a copy of points with each point moved by dx and dy
+func offsetPoints(points []Point, dx, dy int) []Point {
+	moved := make([]Point, len(points))
+	for i, p := range points {
+		moved[i] = Point{p.x + dx, p.y + dy}
+	}
+	return moved
+}
+
+// OffsetBy is the Rectangle implementation of the geometry.OffsetBy method
+func (r Rectangle) OffsetBy(dx, dy int) geometry {
+	r.ll, r.ur = Point{r.ll.x + dx, r.ll.y + dy}, Point{r.ur.x + dx, r.ur.y + dy}
+	return r
+}
+
+// OffsetBy is the Triangle implementation of the geometry.OffsetBy method
+func (t Triangle) OffsetBy(dx, dy int) geometry {
+	pts := offsetPoints([]Point{t.pt0, t.pt1, t.pt2}, dx, dy)
+	t.pt0, t.pt1, t.pt2 = pts[0], pts[1], pts[2]
+	return t
+}
+
+// OffsetBy is the Circle implementation of the geometry.OffsetBy method
+func (c Circle) OffsetBy(dx, dy int) geometry {
+	c.center = Point{c.center.x + dx, c.center.y + dy}
+	return c
+}
+
+// OffsetBy is the Line implementation of the geometry.OffsetBy method
+func (l Line) OffsetBy(dx, dy int) geometry {
+	l.p0, l.p1 = Point{l.p0.x + dx, l.p0.y + dy}, Point{l.p1.x + dx, l.p1.y + dy}
+	return l
+}
+
+// OffsetBy is the Polyline implementation of the geometry.OffsetBy method
+// The moved polyline has its own copy of the points
+func (pl Polyline) OffsetBy(dx, dy int) geometry {
+	pl.points = offsetPoints(pl.points, dx, dy)
+	return pl
+}
+
+// OffsetBy is the Polygon implementation of the geometry.OffsetBy method
+// The moved polygon has its own copy of the vertices
+func (pg Polygon) OffsetBy(dx, dy int) geometry {
+	pg.points = offsetPoints(pg.points, dx, dy)
+	return pg
+}
+
+// Offset is the Rectangle implementation of the geometry.Offset method
+func (r Rectangle) Offset(delta Point) geometry {
+	return r.OffsetBy(delta.x, delta.y)
+}
+
+// Offset is the Triangle implementation of the geometry.Offset method
+func (t Triangle) Offset(delta Point) geometry {
+	return t.OffsetBy(delta.x, delta.y)
+}
+
+// Offset is the Circle implementation of the geometry.Offset method
+func (c Circle) Offset(delta Point) geometry {
+	return c.OffsetBy(delta.x, delta.y)
+}
+
+// Offset is the Line implementation of the geometry.Offset method
+func (l Line) Offset(delta Point) geometry {
+	return l.OffsetBy(delta.x, delta.y)
+}
+
+// Offset is the Polyline implementation of the geometry.Offset method
+func (pl Polyline) Offset(delta Point) geometry {
+	return pl.OffsetBy(delta.x, delta.y)
+}
+
+// Offset is the Polygon implementation of the geometry.Offset method
+func (pg Polygon) Offset(delta Point) geometry {
+	return pg.OffsetBy(delta.x, delta.y)
+}
+
+// OffsetAll returns a new slice with every shape moved by delta
+func OffsetAll(shapes []geometry, delta Point) []geometry {
+	moved := make([]geometry, len(shapes))
+	for i, g := range shapes {
+		moved[i] = g.Offset(delta)
+	}
+	return moved
+}
+
+// outline returns the corner points of the pixels a shape covers
+// This is Vertices for every shape but Rectangle, whose ur corner is exclusive; its outline
+// uses the inclusive corners of its BoundingBox so a one pixel gap is not reported as an overlap
+func outline(g geometry) []Point {
+	if r, ok := g.(Rectangle); ok {
+		b := r.BoundingBox()
+		return []Point{b.Min, {b.Max.x, b.Min.y}, b.Max, {b.Min.x, b.Max.y}}
+	}
+	return g.Vertices()
+}
+
+// edges returns the segments that make up the boundary of a shape, joining the points of its outline
+// Closed shapes join their last vertex back to the first
+func edges(g geometry) (segs [][2]Point) {
+	points := outline(g)
+	closed := true
+	switch g.(type) {
+	case Line, Polyline:
+		closed = false
+	}
+	for i := 1; i < len(points); i++ {
+		segs = append(segs, [2]Point{points[i-1], points[i]})
+	}
+	if closed && len(points) > 2 {
+		segs = append(segs, [2]Point{points[len(points)-1], points[0]})
+	}
+	return segs
+}
+
+// segmentDistance returns the distance from p to the closest point of the segment a-b
+func segmentDistance(p, a, b Point) float64 {
+	dx, dy := float64(b.x-a.x), float64(b.y-a.y)
+	if dx == 0 && dy == 0 {
+		return p.Distance(a)
+	}
+	t := (float64(p.x-a.x)*dx + float64(p.y-a.y)*dy) / (dx*dx + dy*dy)
+	t = math.Max(0, math.Min(1, t))
+	return math.Hypot(float64(p.x)-(float64(a.x)+t*dx), float64(p.y)-(float64(a.y)+t*dy))
+}
+
+// circleIntersects returns true if the circle overlaps a shape made of straight edges
+func circleIntersects(c Circle, g geometry) bool {
+	if g.Contains(c.center) {
+		return true
+	}
+	for _, e := range edges(g) {
+		if segmentDistance(c.center, e[0], e[1]) <= float64(c.r) {
+			return true
+		}
+	}
+	return false
+}
+
+// intersects implements geometry.Intersects for every shape
+// Shapes whose bounding boxes do not overlap are rejected straight away; two circles are
+// compared by the distance between their centers; otherwise the shapes overlap if a corner
+// of one's outline lies inside the other or two of their edges cross
+// The circle tests treat circles as exact, so they may report a false positive
+// for pixels right on a circle's edge
+func intersects(a, b geometry) bool {
+	if !a.BoundingBox().Intersects(b.BoundingBox()) {
+		return false
+	}
+
+	ca, aCircle := a.(Circle)
+	cb, bCircle := b.(Circle)
+	switch {
+	case aCircle && bCircle:
+		return ca.Distance(cb) <= 0
+	case aCircle:
+		return circleIntersects(ca, b)
+	case bCircle:
+		return circleIntersects(cb, a)
+	}
+
+	for _, p := range outline(b) {
+		if a.Contains(p) {
+			return true
+		}
+	}
+	for _, p := range outline(a) {
+		if b.Contains(p) {
+			return true
+		}
+	}
+	for _, ea := range edges(a) {
+		for _, eb := range edges(b) {
+			if segmentsIntersect(ea[0], ea[1], eb[0], eb[1]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Intersects is the Rectangle implementation of the geometry.Intersects method
+func (r Rectangle) Intersects(other geometry) bool {
+	return intersects(r, other)
+}
+
+// Intersects is the Triangle implementation of the geometry.Intersects method
+func (t Triangle) Intersects(other geometry) bool {
+	return intersects(t, other)
+}
+
+// Intersects is the Circle implementation of the geometry.Intersects method
+func (c Circle) Intersects(other geometry) bool {
+	return intersects(c, other)
+}
+
+// Intersects is the Line implementation of the geometry.Intersects method
+func (l Line) Intersects(other geometry) bool {
+	return intersects(l, other)
+}
+
+// Intersects is the Polyline implementation of the geometry.Intersects method
+func (pl Polyline) Intersects(other geometry) bool {
+	return intersects(pl, other)
+}
+
+// Intersects is the Polygon implementation of the geometry.Intersects method
+func (pg Polygon) Intersects(other geometry) bool {
+	return intersects(pg, other)
+}
+
+// OverlappingPairs returns the index pairs i < j of all shapes that intersect each other
+func OverlappingPairs(shapes []geometry) (pairs [][2]int) {
+	for i := range shapes {
+		for j := i + 1; j < len(shapes); j++ {
+			if shapes[i].Intersects(shapes[j]) {
+				pairs = append(pairs, [2]int{i, j})
+			}
+		}
+	}
+	return pairs
+}
+
+// HitTest returns the shapes that contain p, in the order they appear in shapes
+func (d *Display) HitTest(shapes []geometry, p Point) (hits []geometry) {
+	for _, g := range shapes {
+		if g.Contains(p) {
+			hits = append(hits, g)
+		}
+	}
+	return hits
+}
+
+// TopShape returns the last shape in shapes (the topmost in drawing order) that contains p
+// Returns false if no shape contains p
+func (d *Display) TopShape(shapes []geometry, p Point) (geometry, bool) {
+	for i := len(shapes) - 1; i >= 0; i-- {
+		if shapes[i].Contains(p) {
+			return shapes[i], true
+		}
+	}
+	return nil, false
+}
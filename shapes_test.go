@@ -0,0 +1,239 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestRectangle_Vertices checks that a rectangle has its four corners in counter-clockwise order
+func TestRectangle_Vertices(t *testing.T) {
+	r := NewRectangle(Point{1, 2}, Point{7, 5}, NewColor("red"))
+	got := r.Vertices()
+	want := []Point{{1, 2}, {7, 2}, {7, 5}, {1, 5}}
+	if len(got) != 4 {
+		t.Fatalf("got %d vertices, want 4", len(got))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("vertex %d is %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+// TestCircle_Vertices checks the default number of circle vertices and that they lie on the circle
+func TestCircle_Vertices(t *testing.T) {
+	c := NewCircle(Point{20, 20}, 10, NewColor("red"))
+	got := c.Vertices()
+	if len(got) != circleVertexCount {
+		t.Fatalf("got %d vertices, want %d", len(got), circleVertexCount)
+	}
+	if got[0] != (Point{30, 20}) {
+		t.Errorf("first vertex is %v, want (30,20)", got[0])
+	}
+	for _, p := range got {
+		if d2 := (p.x-20)*(p.x-20) + (p.y-20)*(p.y-20); d2 < 81 || d2 > 121 {
+			t.Errorf("vertex %v is not on the circle", p)
+		}
+	}
+}
+
+// TestCentroid_Shapes checks that a circle's centroid is its center and that a rectangle's
+// centroid is equidistant from its four corners
+func TestCentroid_Shapes(t *testing.T) {
+	c := NewCircle(Point{12, 7}, 5, NewColor("red"))
+	if got := c.Centroid(); got != (Point{12, 7}) {
+		t.Errorf("circle centroid is %v, want (12,7)", got)
+	}
+
+	r := NewRectangle(Point{2, 4}, Point{12, 10}, NewColor("red"))
+	center := r.Centroid()
+	corners := r.Vertices()
+	for _, p := range corners[1:] {
+		if got, want := center.Distance(p), center.Distance(corners[0]); got != want {
+			t.Errorf("centroid %v is %v from %v but %v from %v", center, got, p, want, corners[0])
+		}
+	}
+}
+
+// TestCentroidOf_WeightedByArea checks that a larger shape pulls the combined centroid towards itself
+func TestCentroidOf_WeightedByArea(t *testing.T) {
+	small := NewRectangle(Point{0, 0}, Point{2, 2}, NewColor("red"))
+	large := NewRectangle(Point{10, 0}, Point{16, 6}, NewColor("red"))
+	// Areas 4 and 36: x = (1*4 + 13*36) / 40
+	if got, want := CentroidOf([]geometry{small, large}), (Point{12, 3}); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := CentroidOf([]geometry{NewLine(Point{0, 0}, Point{4, 0}, NewColor("red"))}); got != (Point{2, 0}) {
+		t.Errorf("zero-area shapes: got %v, want the plain average (2,0)", got)
+	}
+}
+
+// TestIntersects_Pairs checks circle-circle, rectangle-rectangle and rectangle-circle pairs
+// with known overlapping and non-overlapping cases
+func TestIntersects_Pairs(t *testing.T) {
+	red := NewColor("red")
+	square := NewRectangle(Point{0, 0}, Point{10, 10}, red)
+	for _, tc := range []struct {
+		name string
+		a, b geometry
+		want bool
+	}{
+		{"overlapping circles", NewCircle(Point{10, 10}, 5, red), NewCircle(Point{18, 10}, 5, red), true},
+		{"separate circles", NewCircle(Point{10, 10}, 5, red), NewCircle(Point{21, 10}, 5, red), false},
+		{"overlapping rectangles", square, NewRectangle(Point{9, 0}, Point{20, 10}, red), true},
+		{"adjacent rectangles", square, NewRectangle(Point{10, 0}, Point{20, 10}, red), false},
+		{"circle over the corner", square, NewCircle(Point{18, 18}, 13, red), true},
+		// (9,9) is the last pixel of the square and is 12.7 from the center; the exclusive
+		// corner (10,10) is only 11.3 away
+		{"circle past the corner", square, NewCircle(Point{18, 18}, 12, red), false},
+	} {
+		if got := tc.a.Intersects(tc.b); got != tc.want {
+			t.Errorf("%s: a.Intersects(b) = %v, want %v", tc.name, got, tc.want)
+		}
+		if got := tc.b.Intersects(tc.a); got != tc.want {
+			t.Errorf("%s: b.Intersects(a) = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+// TestOverlappingPairs_Indices checks that only the overlapping pairs are returned, in index order
+func TestOverlappingPairs_Indices(t *testing.T) {
+	red := NewColor("red")
+	shapes := []geometry{
+		NewRectangle(Point{0, 0}, Point{10, 10}, red),
+		NewCircle(Point{40, 40}, 5, red),
+		NewRectangle(Point{5, 5}, Point{15, 15}, red),
+		NewCircle(Point{44, 40}, 2, red),
+	}
+	got := OverlappingPairs(shapes)
+	want := [][2]int{{0, 2}, {1, 3}}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("pair %d is %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+// TestValidate_Degenerate checks that each degenerate shape returns errInvalidShape from Validate
+// and DrawOn instead of drawing nothing or panicking
+func TestValidate_Degenerate(t *testing.T) {
+	red := NewColor("red")
+	for name, g := range map[string]geometry{
+		"empty rectangle":    NewRectangle(Point{5, 5}, Point{5, 9}, red),
+		"inverted rectangle": NewRectangle(Point{9, 9}, Point{2, 2}, red),
+		"collinear triangle": NewTriangle(Point{0, 0}, Point{3, 3}, Point{6, 6}, red),
+		"zero radius circle": NewCircle(Point{5, 5}, 0, red),
+		"negative radius":    NewCircle(Point{5, 5}, -2, red),
+		"two-vertex polygon": NewPolygon(red, Point{0, 0}, Point{5, 5}),
+		"one-point polyline": NewPolyline(red, Point{1, 1}),
+	} {
+		if err := g.Validate(); err != errInvalidShape {
+			t.Errorf("%s: Validate returned %v, want errInvalidShape", name, err)
+		}
+		if err := g.DrawOn(newDisplay(20, 20), DrawDefault); err != errInvalidShape {
+			t.Errorf("%s: DrawOn returned %v, want errInvalidShape", name, err)
+		}
+	}
+	if _, err := NewTri().Vertex(0, 0).Vertex(1, 1).Vertex(2, 2).WithColor("red").Build(); err != errInvalidShape {
+		t.Errorf("collinear triangle: Build returned %v, want errInvalidShape", err)
+	}
+}
+
+// TestShape_Sprint checks that fmt formats shapes with their String method, matching printShape
+func TestShape_Sprint(t *testing.T) {
+	for want, g := range map[string]geometry{
+		"Rectangle: (0,0) to (10,10), fill red, stroke none":                  Rectangle{ur: Point{10, 10}, c: NewColor("red"), Thickness: 1},
+		"Triangle: (0,0), (5,5), (10,0), fill green, stroke black":            NewTriangle(Point{0, 0}, Point{5, 5}, Point{10, 0}, NewColor("green")).WithStroke(NewColor("black")),
+		"Circle: centered around (5,5) with radius 3, fill blue, stroke none": NewCircle(Point{5, 5}, 3, NewColor("blue")),
+	} {
+		if got := fmt.Sprint(g); got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+		if got := g.printShape(); got != want {
+			t.Errorf("printShape: got %q, want %q", got, want)
+		}
+	}
+}
+
+// TestNormalize_SwappedCorners checks that swapped rectangle corners, shuffled triangle vertices and a
+// negative radius normalize to the same form as the correctly constructed shapes
+func TestNormalize_SwappedCorners(t *testing.T) {
+	red := NewColor("red")
+	for _, tc := range []struct{ given, want geometry }{
+		{NewRectangle(Point{10, 8}, Point{2, 3}, red), NewRectangle(Point{2, 3}, Point{10, 8}, red)},
+		{NewRectangle(Point{10, 3}, Point{2, 8}, red), NewRectangle(Point{2, 3}, Point{10, 8}, red)},
+		{NewTriangle(Point{10, 0}, Point{0, 0}, Point{5, 5}, red), NewTriangle(Point{0, 0}, Point{5, 5}, Point{10, 0}, red)},
+		{NewCircle(Point{5, 5}, -3, red), NewCircle(Point{5, 5}, 3, red)},
+	} {
+		if got := tc.given.Normalize(); got != tc.want {
+			t.Errorf("%v normalized to %v, want %v", tc.given, got, tc.want)
+		}
+		if got := tc.want.Normalize(); got != tc.want {
+			t.Errorf("normalizing %v changed it to %v", tc.want, got)
+		}
+	}
+}
+
+// TestParseShape_Normalizes checks that ParseShape returns shapes in their Normalize form
+func TestParseShape_Normalizes(t *testing.T) {
+	got, err := ParseShape("Rectangle: (10,8) to (2,3), fill red, stroke none")
+	if err != nil {
+		t.Fatalf("ParseShape: %v", err)
+	}
+	if want := NewRectangle(Point{2, 3}, Point{10, 8}, NewColor("red")); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, _ := ParseShape("Triangle: (10,0), (5,5), (0,0)"); got != (Triangle{pt1: Point{5, 5}, pt2: Point{10, 0}, Thickness: 1}) {
+		t.Errorf("triangle: got %v", got)
+	}
+}
+
+// TestHitTest_Overlap checks that a point in the overlap of a circle and a rectangle hits both,
+// and that TopShape returns the later one
+func TestHitTest_Overlap(t *testing.T) {
+	red := NewColor("red")
+	circle := NewCircle(Point{10, 10}, 6, red)
+	rect := NewRectangle(Point{12, 8}, Point{30, 20}, red)
+	shapes := []geometry{circle, rect}
+	d := newDisplay(40, 40)
+
+	if hits := d.HitTest(shapes, Point{14, 10}); len(hits) != 2 || hits[0] != circle || hits[1] != rect {
+		t.Errorf("overlap: got %v, want both shapes in order", hits)
+	}
+	if top, ok := d.TopShape(shapes, Point{14, 10}); !ok || top != rect {
+		t.Errorf("TopShape: got %v, %v, want the rectangle", top, ok)
+	}
+	if hits := d.HitTest(shapes, Point{6, 10}); len(hits) != 1 || hits[0] != circle {
+		t.Errorf("circle only: got %v", hits)
+	}
+	if _, ok := d.TopShape(shapes, Point{35, 35}); ok {
+		t.Error("TopShape found a shape at an empty point")
+	}
+}
+
+// TestOffset_Zero checks that offsetting any shape by (0,0) returns an equal shape and that
+// Offset, OffsetBy and OffsetAll move shapes by the same amount
+func TestOffset_Zero(t *testing.T) {
+	red := NewColor("red")
+	shapes := []geometry{
+		NewRectangle(Point{1, 2}, Point{5, 6}, red),
+		NewTriangle(Point{0, 0}, Point{5, 5}, Point{10, 0}, red),
+		NewCircle(Point{5, 5}, 3, red),
+		NewLine(Point{0, 0}, Point{4, 4}, red),
+	}
+	for _, s := range shapes {
+		if got := s.Offset(Point{0, 0}); got.String() != s.String() {
+			t.Errorf("%v offset by (0,0) became %v", s, got)
+		}
+		if a, b := s.Offset(Point{3, -2}), s.OffsetBy(3, -2); a.String() != b.String() {
+			t.Errorf("Offset gave %v but OffsetBy gave %v", a, b)
+		}
+	}
+	moved := OffsetAll(shapes, Point{3, -2})
+	if got, want := moved[0], NewRectangle(Point{4, 0}, Point{8, 4}, red); got != want {
+		t.Errorf("OffsetAll: got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"math"
+)
+
+// Centroid returns the centroid of the triangle, the average of its three vertices
+// Coordinates are truncated to integers
+func (t Triangle) Centroid() Point {
+	return Point{(t.pt0.x + t.pt1.x + t.pt2.x) / 3, (t.pt0.y + t.pt1.y + t.pt2.y) / 3}
+}
+
+// Circumcircle returns the circle passing through all three vertices of the triangle
+// The center is where the perpendicular bisectors of the sides meet, rounded to the nearest pixel
+// The circle has the triangle's fill color; a degenerate (collinear) triangle gives a zero radius
+// circle centered on its centroid
+func (t Triangle) Circumcircle() Circle {
+	ax, ay := float64(t.pt0.x), float64(t.pt0.y)
+	bx, by := float64(t.pt1.x), float64(t.pt1.y)
+	cx, cy := float64(t.pt2.x), float64(t.pt2.y)
+
+	d := 2 * (ax*(by-cy) + bx*(cy-ay) + cx*(ay-by))
+	if d == 0 {
+		return Circle{center: t.Centroid(), c: t.c, Thickness: 1}
+	}
+	a2, b2, c2 := ax*ax+ay*ay, bx*bx+by*by, cx*cx+cy*cy
+	ux := (a2*(by-cy) + b2*(cy-ay) + c2*(ay-by)) / d
+	uy := (a2*(cx-bx) + b2*(ax-cx) + c2*(bx-ax)) / d
+
+	return Circle{
+		center:    Point{int(math.Round(ux)), int(math.Round(uy))},
+		r:         int(math.Round(math.Hypot(ax-ux, ay-uy))),
+		c:         t.c,
+		Thickness: 1,
+	}
+}
+
+// Incircle returns the largest circle that fits inside the triangle
+// The center is the average of the vertices weighted by the opposite side lengths,
+// and the radius is the area divided by the semiperimeter, both rounded to the nearest pixel
+// The circle has the triangle's fill color; a degenerate triangle gives a zero radius circle
+func (t Triangle) Incircle() Circle {
+	a := t.pt1.Distance(t.pt2) // Side opposite pt0
+	b := t.pt2.Distance(t.pt0) // Side opposite pt1
+	c := t.pt0.Distance(t.pt1) // Side opposite pt2
+	perimeter := a + b + c
+	if perimeter == 0 {
+		return Circle{center: t.pt0, c: t.c, Thickness: 1}
+	}
+
+	ix := (a*float64(t.pt0.x) + b*float64(t.pt1.x) + c*float64(t.pt2.x)) / perimeter
+	iy := (a*float64(t.pt0.y) + b*float64(t.pt1.y) + c*float64(t.pt2.y)) / perimeter
+	area := math.Abs(float64(cross(t.pt0, t.pt1, t.pt2))) / 2
+
+	return Circle{
+		center:    Point{int(math.Round(ix)), int(math.Round(iy))},
+		r:         int(math.Round(area / (perimeter / 2))),
+		c:         t.c,
+		Thickness: 1,
+	}
+}
+
+// sideTolerance is how much two side lengths may differ, in pixels, and still count as equal
+const sideTolerance = 0.5
+
+// sides returns the lengths of the sides opposite pt0, pt1 and pt2
+func (t Triangle) sides() [3]float64 {
+	return [3]float64{t.pt1.Distance(t.pt2), t.pt2.Distance(t.pt0), t.pt0.Distance(t.pt1)}
+}
+
+// IsRight returns true if one of the angles is exactly 90°, checked with integer dot products
+// A degenerate triangle is never right
+func (t Triangle) IsRight() bool {
+	if t.Validate() != nil {
+		return false
+	}
+	dot := func(o, a, b Point) int {
+		return (a.x-o.x)*(b.x-o.x) + (a.y-o.y)*(b.y-o.y)
+	}
+	return dot(t.pt0, t.pt1, t.pt2) == 0 || dot(t.pt1, t.pt2, t.pt0) == 0 || dot(t.pt2, t.pt0, t.pt1) == 0
+}
+
+// IsIsosceles returns true if at least two sides have the same length within sideTolerance
+// A degenerate triangle is never isosceles
+func (t Triangle) IsIsosceles() bool {
+	if t.Validate() != nil {
+		return false
+	}
+	s := t.sides()
+	return math.Abs(s[0]-s[1]) <= sideTolerance || math.Abs(s[1]-s[2]) <= sideTolerance ||
+		math.Abs(s[2]-s[0]) <= sideTolerance
+}
+
+// IsEquilateral returns true if all three sides have the same length within sideTolerance
+// A degenerate triangle is never equilateral
+func (t Triangle) IsEquilateral() bool {
+	if t.Validate() != nil {
+		return false
+	}
+	s := t.sides()
+	return math.Max(s[0], math.Max(s[1], s[2]))-math.Min(s[0], math.Min(s[1], s[2])) <= sideTolerance
+}
+
+// Angles returns the interior angles in degrees at pt0, pt1 and pt2, which sum to 180
+// The angle at a vertex that coincides with another one is 0
+func (t Triangle) Angles() [3]float64 {
+	angle := func(o, a, b Point) float64 {
+		if o == a || o == b {
+			return 0
+		}
+		d := math.Abs(math.Atan2(float64(cross(o, a, b)), float64((a.x-o.x)*(b.x-o.x)+(a.y-o.y)*(b.y-o.y))))
+		return d * 180 / math.Pi
+	}
+	return [3]float64{angle(t.pt0, t.pt1, t.pt2), angle(t.pt1, t.pt2, t.pt0), angle(t.pt2, t.pt0, t.pt1)}
+}
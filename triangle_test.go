@@ -0,0 +1,107 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// TestTriangle_FillVertexOrder checks that a filled triangle covers the same pixels
+// whichever order its vertices are given in; the order (c,a,b) used to panic
+func TestTriangle_FillVertexOrder(t *testing.T) {
+	a, b, c := Point{2, 1}, Point{9, 4}, Point{4, 10}
+	want := newDisplay(12, 12)
+	if err := NewTriangle(a, b, c, NewColor("red")).DrawOn(want, DrawFill); err != nil {
+		t.Fatalf("DrawOn: %v", err)
+	}
+
+	for _, o := range [][3]Point{{a, c, b}, {b, a, c}, {b, c, a}, {c, a, b}, {c, b, a}} {
+		d := newDisplay(12, 12)
+		if err := NewTriangle(o[0], o[1], o[2], NewColor("red")).DrawOn(d, DrawFill); err != nil {
+			t.Fatalf("DrawOn %v: %v", o, err)
+		}
+		if !d.Equal(want) {
+			t.Errorf("vertices %v fill different pixels than %v", o, [3]Point{a, b, c})
+		}
+	}
+}
+
+// TestTriangle_RightAngleCircles checks the centroid, circumcircle and incircle of a 6-8-10
+// right triangle; the circumcenter is the midpoint of the hypotenuse
+func TestTriangle_RightAngleCircles(t *testing.T) {
+	red := NewColor("red")
+	tri := NewTriangle(Point{0, 0}, Point{6, 0}, Point{0, 8}, red)
+
+	if got, want := tri.Centroid(), (Point{2, 2}); got != want {
+		t.Errorf("Centroid is %v, want %v", got, want)
+	}
+	if cc := tri.Circumcircle(); cc.center != (Point{3, 4}) || cc.r != 5 || cc.c != red {
+		t.Errorf("Circumcircle is %v, want center (3,4), radius 5, red", cc)
+	}
+	if ic := tri.Incircle(); ic.center != (Point{2, 2}) || ic.r != 2 || ic.c != red {
+		t.Errorf("Incircle is %v, want center (2,2), radius 2, red", ic)
+	}
+}
+
+// TestTriangle_Contains checks the vertices, the centroid and points on each edge (inside) and
+// points just outside each edge, for both vertex orders
+func TestTriangle_Contains(t *testing.T) {
+	red := NewColor("red")
+	for _, tri := range []Triangle{
+		NewTriangle(Point{0, 0}, Point{8, 0}, Point{4, 8}, red),
+		NewTriangle(Point{4, 8}, Point{8, 0}, Point{0, 0}, red),
+	} {
+		inside := []Point{{0, 0}, {8, 0}, {4, 8}, tri.Centroid(), {4, 0}, {6, 4}, {2, 4}}
+		for _, p := range inside {
+			if !tri.Contains(p) {
+				t.Errorf("%v does not contain %v", tri, p)
+			}
+		}
+		for _, p := range []Point{{4, -1}, {7, 4}, {1, 4}, {20, 20}, {4, 9}} {
+			if tri.Contains(p) {
+				t.Errorf("%v contains %v", tri, p)
+			}
+		}
+	}
+}
+
+// TestTriangle_Classify checks the right, isosceles and equilateral predicates and the angles of
+// known triangles, and that a degenerate triangle is none of them
+func TestTriangle_Classify(t *testing.T) {
+	red := NewColor("red")
+	for _, c := range []struct {
+		name                    string
+		tr                      Triangle
+		right, isosceles, equal bool
+		angles                  [3]float64
+	}{
+		{"3-4-5", NewTriangle(Point{0, 0}, Point{4, 0}, Point{0, 3}, red), true, false, false, [3]float64{90, 36.87, 53.13}},
+		{"right isosceles", NewTriangle(Point{0, 0}, Point{10, 0}, Point{0, 10}, red), true, true, false, [3]float64{90, 45, 45}},
+		{"near equilateral", NewTriangle(Point{0, 0}, Point{30, 0}, Point{15, 26}, red), false, true, true, [3]float64{60.02, 60.02, 59.96}},
+		{"scalene", NewTriangle(Point{0, 0}, Point{12, 0}, Point{3, 6}, red), false, false, false, [3]float64{63.43, 33.69, 82.87}},
+	} {
+		if got := c.tr.IsRight(); got != c.right {
+			t.Errorf("%s: IsRight() = %v, want %v", c.name, got, c.right)
+		}
+		if got := c.tr.IsIsosceles(); got != c.isosceles {
+			t.Errorf("%s: IsIsosceles() = %v, want %v", c.name, got, c.isosceles)
+		}
+		if got := c.tr.IsEquilateral(); got != c.equal {
+			t.Errorf("%s: IsEquilateral() = %v, want %v", c.name, got, c.equal)
+		}
+		angles := c.tr.Angles()
+		for i := range angles {
+			if math.Abs(angles[i]-c.angles[i]) > 0.01 {
+				t.Errorf("%s: Angles() = %v, want %v", c.name, angles, c.angles)
+				break
+			}
+		}
+		if sum := angles[0] + angles[1] + angles[2]; math.Abs(sum-180) > 1e-9 {
+			t.Errorf("%s: the angles sum to %v", c.name, sum)
+		}
+	}
+
+	flat := NewTriangle(Point{0, 0}, Point{5, 0}, Point{10, 0}, red)
+	if flat.IsRight() || flat.IsIsosceles() || flat.IsEquilateral() {
+		t.Error("a degenerate triangle was classified")
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"math"
+	"strings"
+)
+
+// Turtle draws Logo-style on a display by moving a pen around
+// d: Display drawn on, x, y: Pen position, angle: Heading in degrees counter-clockwise
+// from the positive x axis, c: Pen color, penDown: Draw while moving
+type Turtle struct {
+	d       *Display // Display drawn on
+	x, y    float64  // Pen position
+	angle   float64  // Heading in degrees, 0 faces right
+	c       Color    // Pen color
+	penDown bool     // Moving draws a line
+}
+
+// NewTurtle creates a turtle at the center of the display, facing right with a black pen down
+func NewTurtle(d *Display) *Turtle {
+	return &Turtle{d: d, x: float64(d.maxX / 2), y: float64(d.maxY / 2), c: NewColor("black"), penDown: true}
+}
+
+// Forward moves the turtle steps pixels along its heading, drawing a line if the pen is down
+// The turtle moves even if the line cannot be drawn
+// Returns the error from DrawLine, such as errOutOfBounds when a pen down line leaves the display
+func (t *Turtle) Forward(steps float64) error {
+	rad := t.angle * math.Pi / 180
+	return t.GoTo(t.x+steps*math.Cos(rad), t.y+steps*math.Sin(rad))
+}
+
+// Backward moves the turtle steps pixels against its heading, like Forward(-steps)
+func (t *Turtle) Backward(steps float64) error {
+	return t.Forward(-steps)
+}
+
+// Left turns the turtle counter-clockwise by deg degrees
+func (t *Turtle) Left(deg float64) {
+	t.angle = math.Mod(t.angle+deg, 360)
+}
+
+// Right turns the turtle clockwise by deg degrees
+func (t *Turtle) Right(deg float64) {
+	t.Left(-deg)
+}
+
+// PenUp lifts the pen so that moving does not draw
+func (t *Turtle) PenUp() {
+	t.penDown = false
+}
+
+// PenDown lowers the pen so that moving draws a line
+func (t *Turtle) PenDown() {
+	t.penDown = true
+}
+
+// SetColor changes the pen color
+// The color is checked when the next line is drawn
+func (t *Turtle) SetColor(c Color) {
+	t.c = c
+}
+
+// GoTo moves the turtle straight to (x,y) without changing its heading,
+// drawing a line if the pen is down
+// Returns the same errors as Forward
+func (t *Turtle) GoTo(x, y float64) (err error) {
+	if t.penDown {
+		err = t.d.DrawLine(int(math.Round(t.x)), int(math.Round(t.y)), int(math.Round(x)), int(math.Round(y)), t.c)
+	}
+	t.x, t.y = x, y
+	return err
+}
+
+// maxLSystemIterations is the largest number of rewrites DrawLSystem accepts;
+// the expanded string grows exponentially with the iterations
+const maxLSystemIterations = 10
+
+// expandLSystem rewrites every character of axiom that has a rule, iterations times
+// Characters without a rule are kept as they are
+func expandLSystem(axiom string, rules map[byte]string, iterations int) string {
+	s := axiom
+	for i := 0; i < iterations; i++ {
+		var b strings.Builder
+		for j := 0; j < len(s); j++ {
+			if r, ok := rules[s[j]]; ok {
+				b.WriteString(r)
+			} else {
+				b.WriteByte(s[j])
+			}
+		}
+		s = b.String()
+	}
+	return s
+}
+
+// DrawLSystem expands the L-system axiom with the rules iterations times and draws the result
+// 'F' moves forward stepLen pixels, '+' turns right and '-' turns left by angle degrees,
+// '[' saves the position and heading and ']' moves back to the last saved ones without drawing
+// Other characters, and a ']' with nothing saved, are ignored
+// Returns errInvalidIterations for negative iterations, errTooManyIterations for more than
+// maxLSystemIterations, or the first error from Forward
+func (t *Turtle) DrawLSystem(axiom string, rules map[byte]string, iterations int, stepLen, angle float64) error {
+	if iterations < 0 {
+		return errInvalidIterations
+	}
+	if iterations > maxLSystemIterations {
+		return errTooManyIterations
+	}
+	type state struct{ x, y, angle float64 }
+	var stack []state
+	for _, ch := range []byte(expandLSystem(axiom, rules, iterations)) {
+		switch ch {
+		case 'F':
+			if err := t.Forward(stepLen); err != nil {
+				return err
+			}
+		case '+':
+			t.Right(angle)
+		case '-':
+			t.Left(angle)
+		case '[':
+			stack = append(stack, state{t.x, t.y, t.angle})
+		case ']':
+			if len(stack) == 0 {
+				continue
+			}
+			s := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			t.x, t.y, t.angle = s.x, s.y, s.angle
+		}
+	}
+	return nil
+}
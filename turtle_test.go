@@ -0,0 +1,66 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// TestTurtle_Square checks that 4 x Forward(50) + Left(90) from the center draws a 50 pixel square
+// and brings the turtle back to where it started
+func TestTurtle_Square(t *testing.T) {
+	red := NewColor("red")
+	d := newDisplay(120, 120)
+	tu := NewTurtle(d)
+	tu.SetColor(red)
+	for i := 0; i < 4; i++ {
+		if err := tu.Forward(50); err != nil {
+			t.Fatalf("Forward: %v", err)
+		}
+		tu.Left(90)
+	}
+	for _, side := range [][4]int{{60, 60, 110, 60}, {110, 60, 110, 110}, {60, 110, 110, 110}, {60, 60, 60, 110}} {
+		for _, err := range d.AssertRegion(side[0], side[1], side[2], side[3], red) {
+			t.Error(err)
+		}
+	}
+	if got := d.Count(red); got != 4*50 {
+		t.Errorf("got %d red pixels, want %d", got, 4*50)
+	}
+	if math.Abs(tu.x-60) > 1e-9 || math.Abs(tu.y-60) > 1e-9 {
+		t.Errorf("the turtle ended at (%v,%v), want (60,60)", tu.x, tu.y)
+	}
+
+	tu.PenUp()
+	if err := tu.GoTo(10, 10); err != nil || d.Count(red) != 4*50 {
+		t.Errorf("moving with the pen up drew a line (err %v)", err)
+	}
+}
+
+// TestTurtle_KochSnowflake checks the Koch snowflake at 3 iterations: 192 segments that close
+// back on the starting point, and errTooManyIterations past the limit
+func TestTurtle_KochSnowflake(t *testing.T) {
+	rules := map[byte]string{'F': "F+F--F+F"}
+	if got := strings.Count(expandLSystem("F--F--F", rules, 3), "F"); got != 3*64 {
+		t.Errorf("got %d segments, want %d", got, 3*64)
+	}
+
+	d := newDisplay(220, 220)
+	tu := NewTurtle(d)
+	tu.PenUp()
+	tu.GoTo(70, 80)
+	tu.PenDown()
+	if err := tu.DrawLSystem("F--F--F", rules, 3, 3, 60); err != nil {
+		t.Fatalf("DrawLSystem: %v", err)
+	}
+	if math.Abs(tu.x-70) > 1e-6 || math.Abs(tu.y-80) > 1e-6 {
+		t.Errorf("the snowflake ends at (%v,%v), want (70,80)", tu.x, tu.y)
+	}
+	// Each segment adds about 3 pixels
+	if got := d.Count(NewColor("black")); got < 3*192*2/3 || got > 4*192 {
+		t.Errorf("got %d pixels for 192 segments of 3", got)
+	}
+	if err := tu.DrawLSystem("F", rules, maxLSystemIterations+1, 1, 60); err != errTooManyIterations {
+		t.Errorf("%d iterations: got %v, want errTooManyIterations", maxLSystemIterations+1, err)
+	}
+}